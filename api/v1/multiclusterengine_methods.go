@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1
 
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"sigs.k8s.io/yaml"
+)
+
 const (
 	// Component names
 	AssistedService                  = "assisted-service"
@@ -160,6 +168,20 @@ var PreviewToStable = map[string]string{
 	ManagedServiceAccountPreview:     ManagedServiceAccount,     // Upgraded in ACM 2.9 / MCE 2.4
 }
 
+/*
+ComponentDependencies maps each component to the components it requires. Together the entries form a
+directed acyclic graph: components are installed after their dependencies and removed before them, and
+an enabled component is not installed until all of its dependencies are.
+*/
+var ComponentDependencies = map[string][]string{
+	ClusterProxyAddon:      {ClusterManager},
+	FleetNavigation:        {ClusterManager},
+	HyperShift:             {ClusterManager},
+	HypershiftLocalHosting: {HyperShift, LocalCluster},
+	MaestroPreview:         {ClusterManager},
+	ManagedServiceAccount:  {ClusterManager},
+}
+
 /*
 ComponentPresent checks if a component with the given name is present in the MultiClusterEngine's Overrides.
 Returns true if the component is present, otherwise false.
@@ -259,6 +281,21 @@ func (mce *MultiClusterEngine) Disable(s string) {
 	})
 }
 
+/*
+DisabledDependencies returns the dependencies of the component with the given name that are explicitly
+disabled in the MultiClusterEngine's Overrides. Dependencies that are not listed are left to the operator
+defaults and are not reported.
+*/
+func (mce *MultiClusterEngine) DisabledDependencies(s string) []string {
+	disabled := []string{}
+	for _, dep := range ComponentDependencies[s] {
+		if mce.ComponentPresent(dep) && !mce.Enabled(dep) {
+			disabled = append(disabled, dep)
+		}
+	}
+	return disabled
+}
+
 /*
 validComponent checks if a ComponentConfig is valid by comparing its name to a list of known component names.
 Returns true if the component is valid, otherwise false.
@@ -297,3 +334,28 @@ func IsACMManaged(mce *MultiClusterEngine) bool {
 	}
 	return false
 }
+
+/*
+DecodePatch converts a patch in YAML or JSON to JSON, checking that it is in the format of the patch type:
+an object for a strategic merge patch and a list of operations for a JSON6902 patch.
+*/
+func DecodePatch(patchType PatchType, patch string) ([]byte, error) {
+	raw, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the patch: %w", err)
+	}
+	switch patchType {
+	case StrategicMergePatchType:
+		object := map[string]interface{}{}
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, fmt.Errorf("a %s patch must be an object: %w", patchType, err)
+		}
+	case JSON6902PatchType:
+		if _, err := jsonpatch.DecodePatch(raw); err != nil {
+			return nil, fmt.Errorf("a %s patch must be a list of operations: %w", patchType, err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", patchType)
+	}
+	return raw, nil
+}
@@ -67,5 +67,27 @@ var _ = Describe("V1 API Methods", func() {
 			Expect(m.Prune(api.Discovery)).To(BeTrue())
 			Expect(m.Prune("test")).To(BeFalse())
 		})
+
+		It("reports explicitly disabled dependencies", func() {
+			m := makeMCE(config(api.HypershiftLocalHosting, true), config(api.HyperShift, false))
+			Expect(m.DisabledDependencies(api.HypershiftLocalHosting)).To(Equal([]string{api.HyperShift}))
+			Expect(m.DisabledDependencies(api.Discovery)).To(BeEmpty())
+		})
+
+		It("decodes patches in the format of their type", func() {
+			raw, err := api.DecodePatch(api.StrategicMergePatchType, "metadata:\n  labels:\n    app: test\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(raw)).To(Equal(`{"metadata":{"labels":{"app":"test"}}}`))
+
+			_, err = api.DecodePatch(api.JSON6902PatchType, "- op: add\n  path: /metadata/labels/app\n  value: test\n")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = api.DecodePatch(api.StrategicMergePatchType, "- op: add")
+			Expect(err).To(HaveOccurred())
+			_, err = api.DecodePatch(api.JSON6902PatchType, "metadata: {}")
+			Expect(err).To(HaveOccurred())
+			_, err = api.DecodePatch("Merge", "metadata: {}")
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
@@ -29,15 +29,25 @@ type AvailabilityType string
 // DeploymentMode
 type DeploymentMode string
 
+// ResourceProfile sizes the resource requests and limits of the components
+type ResourceProfile string
+
 const (
 	// HABasic stands up most app subscriptions with a replicaCount of 1
 	HABasic AvailabilityType = "Basic"
-	// HAHigh stands up most app subscriptions with a replicaCount of 2
+	// HAHigh stands up most app subscriptions with a replicaCount of 2, spread across nodes and zones and
+	// covered by a PodDisruptionBudget
 	HAHigh AvailabilityType = "High"
 	// ModeHosted deploys the MCE on a hosted virtual cluster
 	ModeHosted DeploymentMode = "Hosted"
 	// ModeStandalone deployos the MCE in the default manner
 	ModeStandalone DeploymentMode = "Standalone"
+	// ResourceProfileSmall sizes the components for edge and small hubs managing few clusters
+	ResourceProfileSmall ResourceProfile = "Small"
+	// ResourceProfileMedium keeps the resource requests and limits of the charts
+	ResourceProfileMedium ResourceProfile = "Medium"
+	// ResourceProfileLarge sizes the components for hubs managing large fleets of clusters
+	ResourceProfileLarge ResourceProfile = "Large"
 )
 
 // MultiClusterEngineSpec defines the desired state of MultiClusterEngine
@@ -47,6 +57,13 @@ type MultiClusterEngineSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Availability Configuration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:select:High","urn:alm:descriptor:com.tectonic.ui:select:Basic"}
 	AvailabilityConfig AvailabilityType `json:"availabilityConfig,omitempty"`
 
+	// Sizes the resource requests and limits of the components. Options are: Small, Medium (default) and
+	// Large. The resource overrides of a container apply on top of the profile.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Profile",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:select:Small","urn:alm:descriptor:com.tectonic.ui:select:Medium","urn:alm:descriptor:com.tectonic.ui:select:Large"}
+	//+kubebuilder:validation:Enum=Small;Medium;Large
+	//+optional
+	ResourceProfile ResourceProfile `json:"resourceProfile,omitempty"`
+
 	// Set the nodeselectors
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
@@ -74,6 +91,18 @@ type MultiClusterEngineSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="NetworkPolicies Configuration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	// +optional
 	NetworkPolicies *NetworkPoliciesConfig `json:"networkPolicies,omitempty"`
+	// PodLabels are added to the pods of every component. Labels the charts set are never replaced.
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodAnnotations are added to the pods of every component.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// FootprintBudget is the total of the resource requests the enabled components should stay within. The
+	// admission webhook warns when a change to the MultiClusterEngine exceeds it.
+	// +optional
+	FootprintBudget corev1.ResourceList `json:"footprintBudget,omitempty"`
 }
 
 // NetworkPoliciesConfig provides configuration for NetworkPolicy deployment
@@ -94,12 +123,80 @@ type ComponentConfig struct {
 
 	// ConfigOverrides contains optional configuration overrides for deployments and containers.
 	ConfigOverrides ConfigOverride `json:"configOverrides,omitempty"`
+
+	// NodeSelector overrides the node selector of the MultiClusterEngine for the Deployments of the component.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations overrides the tolerations of the MultiClusterEngine for the Deployments of the component.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity is merged into the affinity of the Deployments of the component.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints replaces the topology spread constraints of the Deployments of the component.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// PodLabels are added to the pods of the component, replacing the pod labels of the MultiClusterEngine with
+	// the same key. Labels the charts set are never replaced.
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodAnnotations are added to the pods of the component, replacing the pod annotations of the
+	// MultiClusterEngine with the same key.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
 }
 
 // ConfigOverride holds overrides for configurations specific to deployments and containers.
 type ConfigOverride struct {
 	// Deployments is a list of deployment specific configuration overrides.
 	Deployments []DeploymentConfig `json:"deployments,omitempty"`
+
+	// Patches is a list of patches applied in order to the rendered resources of the component,
+	// after the deployment overrides.
+	// +optional
+	Patches []ResourcePatch `json:"patches,omitempty"`
+}
+
+// PatchType is the format of a ResourcePatch.
+// +kubebuilder:validation:Enum=StrategicMerge;JSON6902
+type PatchType string
+
+const (
+	// StrategicMergePatchType patches a resource with a strategic merge patch. Resources without strategic
+	// merge metadata, such as custom resources, are patched with a JSON merge patch.
+	StrategicMergePatchType PatchType = "StrategicMerge"
+	// JSON6902PatchType patches a resource with a list of JSON patch (RFC 6902) operations.
+	JSON6902PatchType PatchType = "JSON6902"
+)
+
+// ResourcePatch is a patch of a rendered resource of a component. Exactly one of Patch and ConfigMapRef is set.
+type ResourcePatch struct {
+	// Target selects the rendered resource the patch is applied to.
+	Target PatchTarget `json:"target"`
+
+	// Type is the format of the patch, StrategicMerge or JSON6902.
+	Type PatchType `json:"type"`
+
+	// Patch is the patch, in YAML or JSON.
+	// +optional
+	Patch string `json:"patch,omitempty"`
+
+	// ConfigMapRef selects the key of a ConfigMap in the target namespace that holds the patch.
+	// +optional
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+}
+
+// PatchTarget selects a rendered resource by kind and name.
+type PatchTarget struct {
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
 }
 
 // DeploymentConfig provides configuration details for a specific deployment.
@@ -107,8 +204,14 @@ type DeploymentConfig struct {
 	// Name specifies the name of the deployment being configured.
 	Name string `json:"name"`
 
+	// Replicas overrides the number of replicas of the deployment, which otherwise follows the availabilityConfig.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// Containers is a list of container specific configurations within the deployment.
-	Containers []ContainerConfig `json:"containers"`
+	// +optional
+	Containers []ContainerConfig `json:"containers,omitempty"`
 }
 
 // ContainerConfig holds configuration details for a specific container within a deployment.
@@ -117,7 +220,25 @@ type ContainerConfig struct {
 	Name string `json:"name"`
 
 	// Env is a list of environment variable overrides for the container.
-	Env []EnvConfig `json:"env"`
+	// +optional
+	Env []EnvConfig `json:"env,omitempty"`
+
+	// Resources overrides the resource requests and limits of the container. Only the resources listed are
+	// overridden, the other requests and limits of the container are kept.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Args is a list of arguments appended to the arguments of the container.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Image overrides the image of the container.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy overrides the image pull policy of the container.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 }
 
 // EnvConfig represents an override for an environment variable within a container.
@@ -127,6 +248,25 @@ type EnvConfig struct {
 
 	// Value specifies the value of the environment variable.
 	Value string `json:"value,omitempty"`
+
+	// ValueFrom specifies a source for the value of the environment variable. Cannot be used if value is not empty.
+	// +optional
+	ValueFrom *EnvConfigSource `json:"valueFrom,omitempty"`
+}
+
+// EnvConfigSource represents a source for the value of an environment variable. Exactly one of its fields must be set.
+type EnvConfigSource struct {
+	// SecretKeyRef selects a key of a secret in the namespace of the component.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap in the namespace of the component.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// FieldRef selects a field of the pod, as the fieldRef of a container environment variable.
+	// +optional
+	FieldRef *corev1.ObjectFieldSelector `json:"fieldRef,omitempty"`
 }
 
 // Overrides provides developer overrides for MCE installation
@@ -154,6 +294,9 @@ type MultiClusterEngineStatus struct {
 
 	Components []ComponentCondition `json:"components,omitempty"`
 
+	// ComponentFailures lists the components that failed to reconcile and when they are retried
+	ComponentFailures []ComponentFailure `json:"componentFailures,omitempty"`
+
 	Conditions []MultiClusterEngineCondition `json:"conditions,omitempty"`
 
 	// CurrentVersion is the most recent version successfully installed
@@ -161,6 +304,15 @@ type MultiClusterEngineStatus struct {
 
 	// DesiredVersion is the version the operator is reconciling towards
 	DesiredVersion string `json:"desiredVersion,omitempty"`
+
+	// DriftedResources lists the managed resources whose live state differs from the rendered templates
+	DriftedResources []ResourceDrift `json:"driftedResources,omitempty"`
+
+	// ContainerOverrides lists the containers whose image, image pull policy or arguments are overridden
+	ContainerOverrides []ContainerOverride `json:"containerOverrides,omitempty"`
+
+	// Footprint lists the resources the Deployments of each enabled component run with
+	Footprint []ComponentFootprint `json:"footprint,omitempty"`
 }
 
 // ComponentCondition contains condition information for tracked components
@@ -193,6 +345,94 @@ type ComponentCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// ComponentFailure records a component that failed to reconcile. A failing component is retried with
+// exponential backoff and does not block the reconciliation of other components.
+type ComponentFailure struct {
+	// The component name
+	Name string `json:"name"`
+
+	// The kind of the resource that could not be applied, if known
+	Kind string `json:"kind,omitempty"`
+
+	// The name of the resource that could not be applied, if known
+	ResourceName string `json:"resourceName,omitempty"`
+
+	// Reason is a (brief) reason for the failure.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable message describing the failure.
+	Message string `json:"message,omitempty"`
+
+	// Failures is the number of consecutive failed attempts.
+	Failures int32 `json:"failures,omitempty"`
+
+	// LastFailureTime is the last time the component failed to reconcile.
+	LastFailureTime metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// NextRetryTime is the earliest time the component is reconciled again.
+	NextRetryTime metav1.Time `json:"nextRetryTime,omitempty"`
+}
+
+// ResourceDrift records a managed resource whose live state no longer matches its rendered template.
+type ResourceDrift struct {
+	// The resource kind
+	Kind string `json:"kind"`
+
+	// The resource namespace, empty for cluster scoped resources
+	Namespace string `json:"namespace,omitempty"`
+
+	// The resource name
+	Name string `json:"name"`
+
+	// Fields lists the paths of the fields that differ from the rendered template
+	Fields []string `json:"fields,omitempty"`
+
+	// Overwritten is true when the drift was corrected by reapplying the rendered template
+	Overwritten bool `json:"overwritten,omitempty"`
+}
+
+// ContainerOverride records the image, image pull policy and argument overrides applied to a container.
+type ContainerOverride struct {
+	// The component the container belongs to
+	Component string `json:"component"`
+
+	// The deployment the container belongs to
+	Deployment string `json:"deployment"`
+
+	// The container name
+	Container string `json:"container"`
+
+	// Image is the image the container runs instead of the image of the chart
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy is the image pull policy of the container instead of the pull policy of the chart
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Args lists the arguments appended to the arguments of the container
+	Args []string `json:"args,omitempty"`
+}
+
+// ComponentFootprint is the total of the replicas, containers and resources of the Deployments of a component.
+type ComponentFootprint struct {
+	// The component name
+	Name string `json:"name"`
+
+	// Deployments is the number of Deployments of the component
+	Deployments int32 `json:"deployments"`
+
+	// Replicas is the number of pods the Deployments of the component run
+	Replicas int32 `json:"replicas"`
+
+	// Containers is the number of containers the pods of the component run, init containers excluded
+	Containers int32 `json:"containers"`
+
+	// Requests is the total of the resource requests of the containers of the component
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+
+	// Limits is the total of the resource limits of the containers of the component
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+}
+
 // PhaseType is a summary of the current state of the MultiClusterEngine in its lifecycle
 type PhaseType string
 
@@ -223,8 +463,8 @@ const (
 	*/
 	MultiClusterEngineProgressing MultiClusterEngineConditionType = "Progressing"
 	/*
-		ComponentFailure is added in a deployment when one of its pods fails to be created
-		or deleted.
+		ComponentFailure is added when one or more components fail to reconcile. The failing
+		components are listed in the status componentFailures.
 	*/
 	MultiClusterEngineComponentFailure MultiClusterEngineConditionType = "ComponentFailure"
 	/*
@@ -294,11 +534,16 @@ type MultiClusterEngineList struct {
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Enabled",type="boolean",JSONPath=".spec.enabled",description="Whether the component is enabled"
+// +kubebuilder:printcolumn:name="Health",type="string",JSONPath=".status.health",description="The health of the component"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.lastAppliedVersion",description="The version last applied for the component"
 // +operator-sdk:csv:customresourcedefinitions:displayName="InternalEngineComponent"
 type InternalEngineComponent struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Spec              InternalEngineComponentSpec `json:"spec,omitempty"`
+	Spec              InternalEngineComponentSpec   `json:"spec,omitempty"`
+	Status            InternalEngineComponentStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -308,7 +553,83 @@ type InternalEngineComponentList struct {
 	Items           []InternalEngineComponent `json:"items"`
 }
 
-type InternalEngineComponentSpec struct{}
+// InternalEngineComponentSpec holds the effective configuration of a component, as resolved by the operator
+// from the MultiClusterEngine.
+type InternalEngineComponentSpec struct {
+	// Enabled specifies whether the component is enabled in the MultiClusterEngine.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Images maps each container of the component, as <workload>/<container>, to the image it runs.
+	Images map[string]string `json:"images,omitempty"`
+
+	// ConfigOverrides contains the configuration overrides of the component from the MultiClusterEngine.
+	ConfigOverrides ConfigOverride `json:"configOverrides,omitempty"`
+}
+
+// InternalEngineComponentStatus reports the state of a component as of the last reconcile.
+type InternalEngineComponentStatus struct {
+	// Health summarizes the state of the component. One of Healthy, Progressing or Degraded.
+	Health ComponentHealth `json:"health,omitempty"`
+
+	// AppliedResources lists the resources applied for the component in the last complete reconcile.
+	AppliedResources []AppliedResource `json:"appliedResources,omitempty"`
+
+	// LastAppliedVersion is the operator version that last applied every resource of the component.
+	LastAppliedVersion string `json:"lastAppliedVersion,omitempty"`
+
+	// ConfigHash is the hash of the configuration the resources of the component were last applied with.
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// Failure is the failure recorded when the component last failed to reconcile. It is cleared once the
+	// component is reconciled successfully.
+	Failure *ComponentFailure `json:"failure,omitempty"`
+
+	// DriftedResources lists the resources of the component that differed from their rendered templates when
+	// every resource of the component was last applied.
+	DriftedResources []ResourceDrift `json:"driftedResources,omitempty"`
+
+	// Conditions contains the different condition statuses for the component.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ComponentHealth summarizes the state of a component.
+type ComponentHealth string
+
+const (
+	// ComponentHealthy means every resource of the component was applied and its deployments are available.
+	ComponentHealthy ComponentHealth = "Healthy"
+	// ComponentProgressing means the component is being applied or its deployments are not available yet.
+	ComponentProgressing ComponentHealth = "Progressing"
+	// ComponentDegraded means the component failed to reconcile.
+	ComponentDegraded ComponentHealth = "Degraded"
+)
+
+const (
+	// ComponentApplied is the condition type reporting whether every resource of the component was applied.
+	ComponentApplied = "Applied"
+	// ComponentAvailable is the condition type reporting whether the deployments of the component are available.
+	ComponentAvailable = "Available"
+)
+
+// AppliedResource identifies a resource applied for a component.
+type AppliedResource struct {
+	// APIVersion of the resource.
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resource.
+	// +required
+	Kind string `json:"kind"`
+
+	// Namespace of the resource, empty if cluster scoped.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the resource.
+	// +required
+	Name string `json:"name"`
+}
 
 func init() {
 	SchemeBuilder.Register(&MultiClusterEngine{}, &MultiClusterEngineList{})
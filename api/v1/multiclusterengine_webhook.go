@@ -22,11 +22,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 
 	admissionregistration "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -60,6 +65,10 @@ type BlockDeletionResource struct {
 var (
 	backplaneconfiglog = logf.Log.WithName("backplaneconfig-resource")
 	Client             cl.Client
+	// ComponentFootprintFunc renders a component of the MultiClusterEngine and returns its footprint. It is set
+	// by the operator, as the charts are rendered outside of this package, and is used to count the components
+	// about to be enabled against the footprint budget.
+	ComponentFootprintFunc func(mce *MultiClusterEngine, component string) (ComponentFootprint, error)
 
 	ErrInvalidComponent     = errors.New("invalid component config")
 	ErrInvalidNamespace     = errors.New("invalid TargetNamespace")
@@ -67,7 +76,13 @@ var (
 	ErrInvalidAvailability  = errors.New("invalid AvailabilityConfig")
 	ErrInvalidInfraNS       = errors.New("invalid InfrastructureCustomNamespace")
 	ErrComponentExclusivity = errors.New("component exclusivity violation")
+	ErrUnmetDependency      = errors.New("component dependency not satisfied")
 	ErrMaestroDeprecated    = errors.New("maestro component is deprecated in MCE 5.0 and cannot be enabled")
+	ErrInvalidPatch         = errors.New("invalid component patch")
+	ErrInvalidResources     = errors.New("invalid container resources")
+	ErrInvalidEnv           = errors.New("invalid container environment variable")
+	ErrInvalidPodMetadata   = errors.New("invalid pod labels or annotations")
+	ErrInvalidScheduling    = errors.New("invalid component scheduling")
 
 	blockDeletionResources = []BlockDeletionResource{
 		{
@@ -177,6 +192,10 @@ func (r *MultiClusterEngine) ValidateCreate(ctx context.Context, obj *MultiClust
 		return nil, ErrInvalidAvailability
 	}
 
+	if err := validatePodMetadata(field.NewPath("spec"), obj.Spec.PodLabels, obj.Spec.PodAnnotations); err != nil {
+		return nil, err
+	}
+
 	// Validate components
 	if obj.Spec.Overrides != nil {
 		for _, c := range obj.Spec.Overrides.Components {
@@ -186,6 +205,22 @@ func (r *MultiClusterEngine) ValidateCreate(ctx context.Context, obj *MultiClust
 			if c.Name == MaestroPreview && c.Enabled {
 				return nil, fmt.Errorf("%w: %s cannot be enabled", ErrMaestroDeprecated, c.Name)
 			}
+			if err := validatePatches(c); err != nil {
+				return nil, err
+			}
+			if err := validateResources(c); err != nil {
+				return nil, err
+			}
+			if err := validateEnv(c); err != nil {
+				return nil, err
+			}
+			if err := validateScheduling(c); err != nil {
+				return nil, err
+			}
+			if err := validatePodMetadata(field.NewPath("spec", "overrides", "components").Key(c.Name),
+				c.PodLabels, c.PodAnnotations); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -194,6 +229,10 @@ func (r *MultiClusterEngine) ValidateCreate(ctx context.Context, obj *MultiClust
 		return nil, err
 	}
 
+	if err := obj.validateComponentDependencies(nil); err != nil {
+		return nil, err
+	}
+
 	mceList := &MultiClusterEngineList{}
 	if err := Client.List(ctx, mceList); err != nil {
 		return nil, fmt.Errorf("unable to list BackplaneConfigs: %s", err)
@@ -260,6 +299,10 @@ func (r *MultiClusterEngine) ValidateUpdate(ctx context.Context, oldObj, newObj
 		return nil, ErrInvalidAvailability
 	}
 
+	if err := validatePodMetadata(field.NewPath("spec"), newObj.Spec.PodLabels, newObj.Spec.PodAnnotations); err != nil {
+		return nil, err
+	}
+
 	// Validate components
 	if newObj.Spec.Overrides != nil {
 		for _, c := range newObj.Spec.Overrides.Components {
@@ -269,6 +312,22 @@ func (r *MultiClusterEngine) ValidateUpdate(ctx context.Context, oldObj, newObj
 			if c.Name == MaestroPreview && c.Enabled && !oldObj.Enabled(MaestroPreview) {
 				return nil, fmt.Errorf("%w: %s cannot be enabled", ErrMaestroDeprecated, c.Name)
 			}
+			if err := validatePatches(c); err != nil {
+				return nil, err
+			}
+			if err := validateResources(c); err != nil {
+				return nil, err
+			}
+			if err := validateEnv(c); err != nil {
+				return nil, err
+			}
+			if err := validateScheduling(c); err != nil {
+				return nil, err
+			}
+			if err := validatePodMetadata(field.NewPath("spec", "overrides", "components").Key(c.Name),
+				c.PodLabels, c.PodAnnotations); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -277,6 +336,10 @@ func (r *MultiClusterEngine) ValidateUpdate(ctx context.Context, oldObj, newObj
 		return nil, err
 	}
 
+	if err := newObj.validateComponentDependencies(oldObj); err != nil {
+		return nil, err
+	}
+
 	// Block disable if relevant resources present
 	if newObj.ComponentPresent(Discovery) && !newObj.Enabled(Discovery) {
 		cfg, err := config.GetConfig()
@@ -340,7 +403,7 @@ func (r *MultiClusterEngine) ValidateUpdate(ctx context.Context, oldObj, newObj
 		}
 	}
 
-	return nil, nil
+	return footprintWarnings(oldObj, newObj), nil
 }
 
 var cfg *rest.Config
@@ -409,6 +472,174 @@ func hasIntersection(smallerMap map[string]string, largerMap map[string]string)
 	return false
 }
 
+/*
+validateScheduling ensures the scheduling of the component can be applied. The Deployments of cluster-manager
+are scheduled through the node placement of the ClusterManager, which has no affinity or topology spread
+constraints.
+*/
+func validateScheduling(c ComponentConfig) error {
+	if c.Name == ClusterManager && (c.Affinity != nil || len(c.TopologySpreadConstraints) > 0) {
+		return fmt.Errorf("%w: %s only supports a node selector and tolerations", ErrInvalidScheduling, c.Name)
+	}
+	return nil
+}
+
+/*
+validatePatches ensures every patch of the component targets a resource by kind and name and has exactly
+one of an inline patch, which must be in the format of its type, and a ConfigMap reference.
+*/
+func validatePatches(c ComponentConfig) error {
+	for i, patch := range c.ConfigOverrides.Patches {
+		if patch.Target.Kind == "" || patch.Target.Name == "" {
+			return fmt.Errorf("%w: patch %d of %s must target a kind and a name", ErrInvalidPatch, i, c.Name)
+		}
+		if (patch.Patch == "") == (patch.ConfigMapRef == nil) {
+			return fmt.Errorf("%w: patch %d of %s must set exactly one of patch and configMapRef",
+				ErrInvalidPatch, i, c.Name)
+		}
+		if patch.ConfigMapRef != nil {
+			if patch.Type != StrategicMergePatchType && patch.Type != JSON6902PatchType {
+				return fmt.Errorf("%w: patch %d of %s has unknown type %q", ErrInvalidPatch, i, c.Name, patch.Type)
+			}
+			continue
+		}
+		if _, err := DecodePatch(patch.Type, patch.Patch); err != nil {
+			return fmt.Errorf("%w: patch %d of %s: %v", ErrInvalidPatch, i, c.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateResources ensures no container of the component requests more of a resource than its limit.
+func validateResources(c ComponentConfig) error {
+	for _, deployment := range c.ConfigOverrides.Deployments {
+		for _, container := range deployment.Containers {
+			for name, request := range container.Resources.Requests {
+				if limit, ok := container.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+					return fmt.Errorf("%w: %s request %s of container %s in deployment %s of %s exceeds its limit %s",
+						ErrInvalidResources, name, request.String(), container.Name, deployment.Name, c.Name,
+						limit.String())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+/*
+validateEnv ensures every environment variable override of the component is named and has either a value
+or a source, with exactly one of a secret key, a ConfigMap key and a pod field selected.
+*/
+func validateEnv(c ComponentConfig) error {
+	for _, deployment := range c.ConfigOverrides.Deployments {
+		for _, container := range deployment.Containers {
+			for i, env := range container.Env {
+				if env.Name == "" {
+					return fmt.Errorf("%w: variable %d of container %s in deployment %s of %s must have a name",
+						ErrInvalidEnv, i, container.Name, deployment.Name, c.Name)
+				}
+				if env.ValueFrom == nil {
+					continue
+				}
+				if env.Value != "" {
+					return fmt.Errorf("%w: %s of container %s in deployment %s of %s cannot set both value and valueFrom",
+						ErrInvalidEnv, env.Name, container.Name, deployment.Name, c.Name)
+				}
+				sources := 0
+				for _, set := range []bool{env.ValueFrom.SecretKeyRef != nil, env.ValueFrom.ConfigMapKeyRef != nil,
+					env.ValueFrom.FieldRef != nil} {
+					if set {
+						sources++
+					}
+				}
+				if sources != 1 {
+					return fmt.Errorf("%w: valueFrom of %s of container %s in deployment %s of %s must set exactly one "+
+						"of secretKeyRef, configMapKeyRef and fieldRef", ErrInvalidEnv, env.Name, container.Name,
+						deployment.Name, c.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePodMetadata ensures the pod labels are valid labels and the pod annotations valid annotations.
+func validatePodMetadata(fldPath *field.Path, labels, annotations map[string]string) error {
+	errs := metav1validation.ValidateLabels(labels, fldPath.Child("podLabels"))
+	errs = append(errs, apivalidation.ValidateAnnotations(annotations, fldPath.Child("podAnnotations"))...)
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidPodMetadata, errs.ToAggregate())
+	}
+	return nil
+}
+
+/*
+footprintWarnings warns when the resource requests of the components enabled in newObj exceed its footprint
+budget. The requests of installed components are read from the footprint reported in the status of oldObj,
+and those of the components about to be enabled from their charts, rendered with ComponentFootprintFunc.
+*/
+func footprintWarnings(oldObj, newObj *MultiClusterEngine) admission.Warnings {
+	if len(newObj.Spec.FootprintBudget) == 0 {
+		return nil
+	}
+
+	warnings := admission.Warnings{}
+	reported := map[string]bool{}
+	total := corev1.ResourceList{}
+	addRequests := func(requests corev1.ResourceList) {
+		for name, quantity := range requests {
+			sum := total[name]
+			sum.Add(quantity)
+			total[name] = sum
+		}
+	}
+	for _, footprint := range oldObj.Status.Footprint {
+		reported[footprint.Name] = true
+		if newObj.Enabled(footprint.Name) {
+			addRequests(footprint.Requests)
+		}
+	}
+
+	for _, name := range AllComponents {
+		if !newObj.Enabled(name) || oldObj.Enabled(name) || reported[name] {
+			continue
+		}
+		if ComponentFootprintFunc == nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"the footprint of %s is not known until it is installed and is not counted against the footprint budget",
+				name))
+			continue
+		}
+		footprint, err := ComponentFootprintFunc(newObj, name)
+		if err != nil {
+			backplaneconfiglog.Error(err, "failed to compute the footprint of component", "component", name)
+			warnings = append(warnings, fmt.Sprintf(
+				"the footprint of %s could not be computed and is not counted against the footprint budget: %v",
+				name, err))
+			continue
+		}
+		addRequests(footprint.Requests)
+	}
+
+	names := []string{}
+	for name := range newObj.Spec.FootprintBudget {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		budget := newObj.Spec.FootprintBudget[corev1.ResourceName(name)]
+		if requested, ok := total[corev1.ResourceName(name)]; ok && requested.Cmp(budget) > 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"the %s requests of the enabled components total %s, which exceeds the footprint budget of %s",
+				name, requested.String(), budget.String()))
+		}
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
 func contains(s []string, v string) bool {
 	for _, vs := range s {
 		if vs == v {
@@ -473,3 +704,25 @@ func (r *MultiClusterEngine) validateComponentExclusivity() error {
 
 	return nil
 }
+
+/*
+validateComponentDependencies rejects components enabled by the request whose dependencies are explicitly
+disabled (see ComponentDependencies). On create, a component is enabled by the request when it is listed as
+enabled in the overrides; on update, when it was not enabled before. Components that were already enabled,
+such as those the operator enables by default, are not rejected when one of their dependencies is disabled:
+the controller reports them as blocked on their dependencies instead.
+*/
+func (r *MultiClusterEngine) validateComponentDependencies(oldObj *MultiClusterEngine) error {
+	if r.Spec.Overrides == nil {
+		return nil
+	}
+	for _, c := range r.Spec.Overrides.Components {
+		if !c.Enabled || (oldObj != nil && oldObj.Enabled(c.Name)) {
+			continue
+		}
+		if disabled := r.DisabledDependencies(c.Name); len(disabled) > 0 {
+			return fmt.Errorf("%w: %s requires %s to be enabled", ErrUnmetDependency, c.Name, disabled[0])
+		}
+	}
+	return nil
+}
@@ -8,7 +8,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
@@ -125,6 +127,30 @@ var _ = Describe("Multiclusterengine webhook", func() {
 				}
 				Expect(k8sClient.Create(ctx, mce)).NotTo(BeNil(), "maestro-preview component should be blocked")
 			})
+			By("because of an unmet component dependency", func() {
+				mce := &MultiClusterEngine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        fmt.Sprintf("%s-dependency", multiClusterEngineName),
+						Annotations: map[string]string{"deploymentmode": string(ModeHosted)},
+					},
+					Spec: MultiClusterEngineSpec{
+						TargetNamespace: "dependency-ns",
+						Overrides: &Overrides{
+							Components: []ComponentConfig{
+								{
+									Name:    HypershiftLocalHosting,
+									Enabled: true,
+								},
+								{
+									Name:    HyperShift,
+									Enabled: false,
+								},
+							},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, mce)).NotTo(BeNil(), "hypershift-local-hosting requires hypershift")
+			})
 		})
 
 		It("Should fail to update multiclusterengine", func() {
@@ -173,6 +199,127 @@ var _ = Describe("Multiclusterengine webhook", func() {
 				Expect(k8sClient.Update(ctx, mce)).NotTo(BeNil(), "maestro-preview cannot be enabled")
 			})
 
+			By("because of an unmet component dependency", func() {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: multiClusterEngineName}, mce)).To(Succeed())
+				mce.Spec.Overrides = &Overrides{
+					Components: []ComponentConfig{
+						{
+							Name:    HypershiftLocalHosting,
+							Enabled: true,
+						},
+						{
+							Name:    LocalCluster,
+							Enabled: false,
+						},
+					},
+				}
+				Expect(k8sClient.Update(ctx, mce)).NotTo(BeNil(), "hypershift-local-hosting requires local-cluster")
+			})
+
+			By("because of an affinity for cluster-manager", func() {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: multiClusterEngineName}, mce)).To(Succeed())
+				mce.Spec.Overrides = &Overrides{
+					Components: []ComponentConfig{
+						{
+							Name:     ClusterManager,
+							Enabled:  true,
+							Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}},
+						},
+					},
+				}
+				Expect(k8sClient.Update(ctx, mce)).NotTo(BeNil(), "the ClusterManager has no affinity to set")
+			})
+
+			By("because of an invalid patch", func() {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: multiClusterEngineName}, mce)).To(Succeed())
+				mce.Spec.Overrides = &Overrides{
+					Components: []ComponentConfig{
+						{
+							Name:    ConsoleMCE,
+							Enabled: true,
+							ConfigOverrides: ConfigOverride{
+								Patches: []ResourcePatch{{
+									Target: PatchTarget{Kind: "Deployment", Name: "console-mce-console"},
+									Type:   JSON6902PatchType,
+									Patch:  "metadata: {}",
+								}},
+							},
+						},
+					},
+				}
+				Expect(k8sClient.Update(ctx, mce)).NotTo(BeNil(), "patches not in the format of their type should not be permitted")
+			})
+
+			By("because of resource requests exceeding their limits", func() {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: multiClusterEngineName}, mce)).To(Succeed())
+				mce.Spec.Overrides = &Overrides{
+					Components: []ComponentConfig{
+						{
+							Name:    Hive,
+							Enabled: true,
+							ConfigOverrides: ConfigOverride{
+								Deployments: []DeploymentConfig{{
+									Name: "hive-operator",
+									Containers: []ContainerConfig{{
+										Name: "hive-operator",
+										Resources: corev1.ResourceRequirements{
+											Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+											Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+										},
+									}},
+								}},
+							},
+						},
+					},
+				}
+				Expect(k8sClient.Update(ctx, mce)).NotTo(BeNil(), "requests exceeding their limits should not be permitted")
+			})
+
+			By("because of an environment variable with both a value and a source", func() {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: multiClusterEngineName}, mce)).To(Succeed())
+				mce.Spec.Overrides = &Overrides{
+					Components: []ComponentConfig{
+						{
+							Name:    Hive,
+							Enabled: true,
+							ConfigOverrides: ConfigOverride{
+								Deployments: []DeploymentConfig{{
+									Name: "hive-operator",
+									Containers: []ContainerConfig{{
+										Name: "hive-operator",
+										Env: []EnvConfig{{
+											Name:  "HTTP_PROXY",
+											Value: "http://proxy:3128",
+											ValueFrom: &EnvConfigSource{
+												SecretKeyRef: &corev1.SecretKeySelector{
+													LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+													Key:                  "url",
+												},
+											},
+										}},
+									}},
+								}},
+							},
+						},
+					},
+				}
+				Expect(k8sClient.Update(ctx, mce)).NotTo(BeNil(), "variables with both a value and a source should not be permitted")
+			})
+
+			By("because of an invalid pod label", func() {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: multiClusterEngineName}, mce)).To(Succeed())
+				mce.Spec.Overrides = &Overrides{
+					Components: []ComponentConfig{
+						{
+							Name:      Hive,
+							Enabled:   true,
+							PodLabels: map[string]string{"cost center": "hub"},
+						},
+					},
+				}
+				Expect(k8sClient.Update(ctx, mce)).NotTo(BeNil(), "invalid pod labels should not be permitted")
+			})
+
 			By("because of existing local-cluster resource", func() {
 				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: multiClusterEngineName}, mce)).To(Succeed())
 				managedCluster := NewManagedCluster(mce.Spec.LocalClusterName)
@@ -195,6 +342,106 @@ var _ = Describe("Multiclusterengine webhook", func() {
 			})
 		})
 
+		It("Should leave components enabled by default blocked on their dependencies", func() {
+			oldMCE := &MultiClusterEngine{
+				Spec: MultiClusterEngineSpec{
+					Overrides: &Overrides{Components: []ComponentConfig{
+						{Name: HypershiftLocalHosting, Enabled: true},
+						{Name: HyperShift, Enabled: true},
+						{Name: LocalCluster, Enabled: true},
+					}},
+				},
+			}
+
+			By("allowing local-cluster to be disabled", func() {
+				newMCE := oldMCE.DeepCopy()
+				newMCE.Disable(LocalCluster)
+				Expect(newMCE.validateComponentDependencies(oldMCE)).To(Succeed())
+			})
+
+			By("allowing hypershift to be disabled", func() {
+				newMCE := oldMCE.DeepCopy()
+				newMCE.Disable(HyperShift)
+				Expect(newMCE.validateComponentDependencies(oldMCE)).To(Succeed())
+			})
+
+			By("allowing cluster-api to be enabled in place of hypershift", func() {
+				newMCE := oldMCE.DeepCopy()
+				newMCE.Disable(HyperShift)
+				newMCE.Disable(HypershiftLocalHosting)
+				newMCE.Enable(ClusterAPI)
+				Expect(newMCE.validateComponentDependencies(oldMCE)).To(Succeed())
+				Expect(newMCE.validateComponentExclusivity()).To(Succeed())
+			})
+
+			By("rejecting a component enabled with a disabled dependency", func() {
+				previous := oldMCE.DeepCopy()
+				previous.Disable(HypershiftLocalHosting)
+				previous.Disable(HyperShift)
+				newMCE := previous.DeepCopy()
+				newMCE.Enable(HypershiftLocalHosting)
+				Expect(newMCE.validateComponentDependencies(previous)).To(MatchError(ErrUnmetDependency))
+			})
+
+			By("rejecting an add-on enabled with the cluster manager disabled", func() {
+				previous := oldMCE.DeepCopy()
+				previous.Disable(ClusterManager)
+				newMCE := previous.DeepCopy()
+				newMCE.Enable(ManagedServiceAccount)
+				Expect(newMCE.validateComponentDependencies(previous)).To(MatchError(ErrUnmetDependency))
+			})
+		})
+
+		It("Should warn when the footprint budget is exceeded", func() {
+			oldMCE := &MultiClusterEngine{
+				Spec: MultiClusterEngineSpec{
+					Overrides: &Overrides{Components: []ComponentConfig{{Name: Hive, Enabled: true}}},
+				},
+				Status: MultiClusterEngineStatus{
+					Footprint: []ComponentFootprint{{
+						Name: Hive,
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("200m"),
+							corev1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+					}},
+				},
+			}
+			newMCE := oldMCE.DeepCopy()
+			newMCE.Spec.FootprintBudget = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+
+			By("not warning within the budget", func() {
+				Expect(footprintWarnings(oldMCE, newMCE)).To(BeEmpty())
+			})
+
+			By("warning above the budget", func() {
+				newMCE.Spec.FootprintBudget[corev1.ResourceMemory] = resource.MustParse("256Mi")
+				warnings := footprintWarnings(oldMCE, newMCE)
+				Expect(warnings).To(HaveLen(1))
+				Expect(warnings[0]).To(ContainSubstring("512Mi"))
+			})
+
+			By("warning about components not installed yet", func() {
+				newMCE.Spec.FootprintBudget[corev1.ResourceMemory] = resource.MustParse("1Gi")
+				newMCE.Enable(Discovery)
+				warnings := footprintWarnings(oldMCE, newMCE)
+				Expect(warnings).To(HaveLen(1))
+				Expect(warnings[0]).To(ContainSubstring(Discovery))
+			})
+
+			By("counting components about to be enabled against the budget", func() {
+				ComponentFootprintFunc = func(mce *MultiClusterEngine, component string) (ComponentFootprint, error) {
+					return ComponentFootprint{Name: component, Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("768Mi"),
+					}}, nil
+				}
+				defer func() { ComponentFootprintFunc = nil }()
+				warnings := footprintWarnings(oldMCE, newMCE)
+				Expect(warnings).To(HaveLen(1))
+				Expect(warnings[0]).To(ContainSubstring("1280Mi"))
+			})
+		})
+
 		It("Should fail to delete multiclusterengine when AgentServiceConfig exists", func() {
 			mce := &MultiClusterEngine{}
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: multiClusterEngineName}, mce)).To(Succeed())
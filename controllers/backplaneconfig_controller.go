@@ -34,10 +34,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -47,9 +49,9 @@ import (
 	"github.com/stolostron/backplane-operator/pkg/overrides"
 	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/status"
-	"github.com/stolostron/backplane-operator/pkg/toggle"
 	"github.com/stolostron/backplane-operator/pkg/utils"
 	"github.com/stolostron/backplane-operator/pkg/version"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
 	clustermanager "open-cluster-management.io/api/operator/v1"
@@ -92,13 +94,25 @@ type MultiClusterEngineReconciler struct {
 	Scheme           *runtime.Scheme
 	Images           map[string]string
 	StatusManager    *status.StatusTracker
+	Recorder         events.EventRecorder
 	Log              logr.Logger
 	UpgradeableCond  utils.Condition
 	DeprecatedFields map[string]bool
 	OLMVersion       string
+
+	// componentLocks serializes the reconciles of each component by the MultiClusterEngine and
+	// InternalEngineComponent controllers
+	componentLocks componentLocks
+	// cacheMu guards CacheSpec, which is read by the InternalEngineComponent controller while the
+	// MultiClusterEngine is reconciled
+	cacheMu sync.RWMutex
+
+	// renderCache shares the charts rendered for the NetworkPolicy, apply and delete paths of a reconcile
+	renderCache renderer.RenderCache
 }
 
 const (
+	crdsDir            = "pkg/templates/crds"
 	requeuePeriod      = 15 * time.Second
 	backplaneFinalizer = "finalizer.multicluster.openshift.io"
 
@@ -177,7 +191,8 @@ var (
 // +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenrequests,verbs=create
 
 // InternalEngineComponent
-// +kubebuilder:rbac:groups="multicluster.openshift.io",resources="internalenginecomponents",verbs=create;get;delete;patch;list;watch
+// +kubebuilder:rbac:groups="multicluster.openshift.io",resources="internalenginecomponents",verbs=create;get;delete;patch;update;list;watch
+// +kubebuilder:rbac:groups="multicluster.openshift.io",resources="internalenginecomponents/status",verbs=get;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -197,10 +212,6 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, nil
 	}
 
-	// reset the status conditions for failures that has occurred in previous iterations.
-	backplaneConfig.Status.Conditions = status.FilterOutConditionWithSubString(backplaneConfig.Status.Conditions,
-		backplanev1.MultiClusterEngineComponentFailure)
-
 	// Check if any deprecated fields are present within the backplaneConfig spec.
 	r.CheckDeprecatedFieldUsage(backplaneConfig)
 
@@ -220,6 +231,9 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 	for _, c := range backplaneConfig.Status.Conditions {
 		r.StatusManager.AddCondition(c)
 	}
+	for _, f := range backplaneConfig.Status.ComponentFailures {
+		r.StatusManager.SetFailure(f)
+	}
 
 	// Check for externally managed components and add warning condition
 	r.checkExternallyManagedComponents(backplaneConfig)
@@ -241,6 +255,7 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 	defer func() {
 		r.Log.Info("Updating status")
 		backplaneConfig.Status = r.StatusManager.ReportStatus(*backplaneConfig)
+		reportDriftMetrics(backplaneConfig.Status.DriftedResources)
 		err := r.Client.Status().Update(ctx, backplaneConfig)
 		if backplaneConfig.Status.Phase != backplanev1.MultiClusterEnginePhaseAvailable && !utils.IsPaused(backplaneConfig) {
 			retRes = ctrl.Result{RequeueAfter: requeuePeriod}
@@ -424,9 +439,11 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 
 	// Update cache with image overrides and related information.
+	r.cacheMu.Lock()
 	r.CacheSpec.ImageOverrides = imageOverrides
 	r.CacheSpec.ImageRepository = utils.GetImageRepository(backplaneConfig)
 	r.CacheSpec.ImageOverridesCM = utils.GetImageOverridesConfigmapName(backplaneConfig)
+	r.cacheMu.Unlock()
 
 	// Attempt to retrieve template overrides from environmental variables.
 	templateOverrides := overrides.GetOverridesFromEnv(overrides.TemplateOverridePrefix)
@@ -445,8 +462,10 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 
 	// Update cache with template overrides and related information.
+	r.cacheMu.Lock()
 	r.CacheSpec.TemplateOverrides = templateOverrides
 	r.CacheSpec.TemplateOverridesCM = utils.GetTemplateOverridesConfigmapName(backplaneConfig)
+	r.cacheMu.Unlock()
 
 	// Do not reconcile objects if this instance of mce is labeled "paused"
 	if utils.IsPaused(backplaneConfig) {
@@ -462,41 +481,13 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, nil
 	}
 
-	var crdsDir string
-	if val, ok := os.LookupEnv("UNIT_TEST"); ok && val == "true" {
-		crdsDir = "test/unit-test-crds"
-	} else {
-		crdsDir = "pkg/templates/crds"
-	}
-
-	// Collect CRD directories to exclude from rendering
-	// 1. Externally managed components - CRDs owned by external operators
-	externallyManagedCRDDirs := r.getExternallyManagedCRDSkipDirectories(backplaneConfig)
-
-	// 2. Disabled components - prevents CRD updates for disabled features
-	disabledComponentCRDDirs := r.getDisabledComponentCRDSkipDirectories(backplaneConfig)
-
-	// 3. Platform-specific variants - ensures only the appropriate variant (OCP or K8s) is deployed
-	platformSpecificCRDDirs := r.getPlatformSpecificCRDSkipDirectories()
-
-	// Merge all skip lists and deduplicate using a map
-	dirMap := make(map[string]bool)
-	for _, dir := range externallyManagedCRDDirs {
-		dirMap[dir] = true
-	}
-	for _, dir := range disabledComponentCRDDirs {
-		dirMap[dir] = true
-	}
-	for _, dir := range platformSpecificCRDDirs {
-		dirMap[dir] = true
-	}
-
-	// Convert merged skip list back to slice for rendering
-	mergedSkipCRDDirs := []string{}
-	for dir := range dirMap {
-		mergedSkipCRDDirs = append(mergedSkipCRDDirs, dir)
+	// In plan mode only report what would change, without applying CRDs or components
+	if utils.IsPlanMode(backplaneConfig) {
+		r.Log.Info("MultiClusterEngine is in plan mode. Planning changes without applying them.")
+		return r.planChanges(ctx, backplaneConfig)
 	}
 
+	mergedSkipCRDDirs := r.getCRDSkipDirectories(backplaneConfig)
 	crds, errs := renderer.RenderCRDs(crdsDir, backplaneConfig, mergedSkipCRDDirs)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -518,35 +509,47 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 	}
 
-	result, err = r.DeployAlwaysSubcomponents(ctx, backplaneConfig)
-	if err != nil {
-		cond := status.NewCondition(
-			backplanev1.MultiClusterEngineProgressing,
-			metav1.ConditionUnknown,
-			status.DeployFailedReason,
-			err.Error(),
-		)
-		r.StatusManager.AddCondition(cond)
-		return result, err
+	/*
+		Component failures are isolated: an error from one stage is recorded against the failing
+		component and the remaining stages still run. The collected errors are returned once the
+		reconcile is complete.
+	*/
+	componentErrs := []error{}
+	componentResult := ctrl.Result{}
+	collect := func(result ctrl.Result, err error) {
+		if err != nil {
+			componentErrs = append(componentErrs, err)
+		}
+		if result.RequeueAfter > 0 &&
+			(componentResult.RequeueAfter == 0 || result.RequeueAfter < componentResult.RequeueAfter) {
+			componentResult.RequeueAfter = result.RequeueAfter
+		}
 	}
 
-	result, err = r.ensureToggleableComponents(ctx, backplaneConfig)
+	// Resources applied per component are recorded so that those no longer rendered can be pruned
+	previousInventory, err := r.loadInventory(ctx, backplaneConfig)
 	if err != nil {
-		return result, err
+		return ctrl.Result{}, err
 	}
+	inventory := newResourceInventory(previousInventory)
+	inventoryCtx := withInventory(ctx, inventory, "")
+
+	collect(r.applyComponent(inventoryCtx, alwaysDeployedComponent, func(ctx context.Context) (ctrl.Result, error) {
+		return r.DeployAlwaysSubcomponents(ctx, backplaneConfig)
+	}))
+
+	collect(r.ensureToggleableComponents(inventoryCtx, backplaneConfig))
 
 	/*
 		Ensure NetworkPolicies for MCE components. This implements a create-once pattern where
 		MCE creates initial NetworkPolicy resources. Operand teams then adopt and manage these
 		policies. MCE does not continuously reconcile after creation.
 	*/
-	result, err = r.ensureNetworkPolicies(ctx, backplaneConfig)
-	if result != (ctrl.Result{}) || err != nil {
-		if err != nil {
-			r.Log.Error(err, "Failed to ensure NetworkPolicies")
-		}
-		return result, err
-	}
+	collect(r.reconcileWithBackoff(networkPoliciesComponent, func() (ctrl.Result, error) {
+		return r.ensureNetworkPolicies(ctx, backplaneConfig)
+	}))
+
+	collect(r.pruneInventory(ctx, backplaneConfig, inventory))
 
 	result, err = r.createTrustBundleConfigmap(ctx, backplaneConfig)
 	if err != nil {
@@ -569,6 +572,16 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return result, err
 	}
 
+	if len(componentErrs) > 0 {
+		err = errors.Join(componentErrs...)
+		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing,
+			metav1.ConditionUnknown, status.DeployFailedReason, err.Error()))
+		return ctrl.Result{}, err
+	}
+	if componentResult != (ctrl.Result{}) {
+		return componentResult, nil
+	}
+
 	if upgrade {
 		return ctrl.Result{Requeue: true}, nil
 	}
@@ -630,12 +643,19 @@ func (r *MultiClusterEngineReconciler) setOperatorUpgradeableStatus(ctx context.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MultiClusterEngineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	changed := predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{},
+		predicate.AnnotationChangedPredicate{})
 	mceBuilder := ctrl.NewControllerManagedBy(mgr).
-		For(&backplanev1.MultiClusterEngine{}).
-		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{},
-			predicate.AnnotationChangedPredicate{})).
+		For(&backplanev1.MultiClusterEngine{}, builder.WithPredicates(changed)).
+		// Deployments of a component are reconciled by the InternalEngineComponent controller
 		Watches(&appsv1.Deployment{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &backplanev1.MultiClusterEngine{}),
+			builder.WithPredicates(predicate.Not(componentLabeled), changed),
+		).
+		// The status of the components is aggregated when their health, failure or drift changes
+		Watches(&backplanev1.InternalEngineComponent{},
+			handler.EnqueueRequestsFromMapFunc(r.internalEngineComponentRequests),
+			builder.WithPredicates(componentStatusChanged),
 		).
 		Watches(&hiveconfig.HiveConfig{}, &handler.Funcs{
 			DeleteFunc: func(ctx context.Context, e event.TypedDeleteEvent[client.Object],
@@ -690,7 +710,7 @@ func (r *MultiClusterEngineReconciler) SetupWithManager(mgr ctrl.Manager) error
 						}
 					}
 					return req
-				}))
+				}), builder.WithPredicates(changed))
 	}
 
 	return mceBuilder.Complete(r)
@@ -895,10 +915,7 @@ func (r *MultiClusterEngineReconciler) DeployAlwaysSubcomponents(ctx context.Con
 		r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Applies all templates
@@ -943,6 +960,7 @@ func (r *MultiClusterEngineReconciler) ensureInternalEngineComponent(
 			Name:      component,
 			Namespace: backplaneConfig.Spec.TargetNamespace,
 		},
+		Spec: internalEngineComponentSpec(backplaneConfig, component),
 	}
 
 	if err := r.Client.Get(
@@ -1011,47 +1029,8 @@ func (r *MultiClusterEngineReconciler) ensureNoInternalEngineComponent(ctx conte
 }
 
 func (r *MultiClusterEngineReconciler) fetchChartOrCRDPath(component string) string {
-	var clusterAPIChartLoc string
-	var clusterAPIAzureChartLoc string
-	var clusterAPIMetalChartLoc string
-	var clusterAPIOAChartLoc string
-
-	if utils.DeployOnOCP() {
-		clusterAPIChartLoc = toggle.ClusterAPIChartDir
-		clusterAPIAzureChartLoc = toggle.ClusterAPIProviderAzureChartDir
-		clusterAPIMetalChartLoc = toggle.ClusterAPIProviderMetalChartDir
-		clusterAPIOAChartLoc = toggle.ClusterAPIProviderOAChartDir
-	} else {
-		clusterAPIChartLoc = toggle.ClusterAPIK8SChartDir
-		clusterAPIAzureChartLoc = toggle.ClusterAPIProviderAzureK8SChartDir
-		clusterAPIMetalChartLoc = toggle.ClusterAPIProviderMetalK8SChartDir
-		clusterAPIOAChartLoc = toggle.ClusterAPIProviderOAK8SChartDir
-	}
-
-	chartDirs := map[string]string{
-		backplanev1.AssistedService:                toggle.AssistedServiceChartDir,
-		backplanev1.ClusterAPI:                     clusterAPIChartLoc,
-		backplanev1.ClusterAPIProviderAWS:          toggle.ClusterAPIProviderAWSChartDir,
-		backplanev1.ClusterAPIProviderAzurePreview: clusterAPIAzureChartLoc,
-		backplanev1.ClusterAPIProviderMetal:        clusterAPIMetalChartLoc,
-		backplanev1.ClusterAPIProviderOA:           clusterAPIOAChartLoc,
-		backplanev1.ClusterLifecycle:               toggle.ClusterLifecycleChartDir,
-		backplanev1.ClusterManager:                 toggle.ClusterManagerChartDir,
-		backplanev1.ClusterPermission:              toggle.ClusterPermissionChartDir,
-		backplanev1.ClusterProxyAddon:              toggle.ClusterProxyAddonDir,
-		backplanev1.ConsoleMCE:                     toggle.ConsoleMCEChartsDir,
-		backplanev1.Discovery:                      toggle.DiscoveryChartDir,
-		backplanev1.FleetNavigation:                toggle.FleetNavigationChartDir,
-		backplanev1.Hive:                           toggle.HiveChartDir,
-		backplanev1.HyperShift:                     toggle.HyperShiftChartDir,
-		backplanev1.ImageBasedInstallOperator:      toggle.ImageBasedInstallOperatorChartDir,
-		backplanev1.ManagedServiceAccount:          toggle.ManagedServiceAccountChartDir,
-		backplanev1.ServerFoundation:               toggle.ServerFoundationChartDir,
-		backplanev1.MaestroPreview:                 toggle.MaestroChartDir,
-	}
-
-	if dir, exists := chartDirs[component]; exists {
-		return dir
+	if c, ok := lookupComponent(component); ok {
+		return c.GetChartDir()
 	}
 
 	// Log and return a default path for chart directory not found
@@ -1062,470 +1041,118 @@ func (r *MultiClusterEngineReconciler) fetchChartOrCRDPath(component string) str
 func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Context,
 	backplaneConfig *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
 	errs := map[string]error{}
-	requeue := false
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ManagedServiceAccount) {
-		if backplaneConfig.Enabled(backplanev1.ManagedServiceAccount) && foundation.CanInstallAddons(ctx, r.Client) {
-			result, err := r.ensureManagedServiceAccount(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ManagedServiceAccount] = err
-			}
-		} else {
-			result, err := r.ensureNoManagedServiceAccount(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ManagedServiceAccount] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ManagedServiceAccount)
-	}
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.FleetNavigation) {
-		if backplaneConfig.Enabled(backplanev1.FleetNavigation) && foundation.CanInstallAddons(ctx, r.Client) {
-			result, err := r.ensureFleetNavigation(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.FleetNavigation] = err
-			}
-		} else {
-			result, err := r.ensureNoFleetNavigation(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.FleetNavigation] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.FleetNavigation)
-	}
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ImageBasedInstallOperator) {
-		if backplaneConfig.Enabled(backplanev1.ImageBasedInstallOperator) {
-			result, err := r.ensureImageBasedInstallOperator(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ImageBasedInstallOperator] = err
-			}
-		} else {
-			result, err := r.ensureNoImageBasedInstallOperator(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ImageBasedInstallOperator] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ImageBasedInstallOperator)
-	}
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.HyperShift) {
-		if backplaneConfig.Enabled(backplanev1.HyperShift) && foundation.CanInstallAddons(ctx, r.Client) {
-			result, err := r.ensureHyperShift(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.HyperShift] = err
-			}
-		} else {
-			result, err := r.ensureNoHyperShift(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.HyperShift] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.HyperShift)
-	}
 
-	result, err := r.reconcileHypershiftLocalHosting(ctx, backplaneConfig)
-	if result != (ctrl.Result{}) {
-		requeue = true
-	}
+	components, err := componentInstallOrder(registeredComponents())
 	if err != nil {
-		errs[backplanev1.HypershiftLocalHosting] = err
-	}
-
-	if utils.DeployOnOCP() {
-		ocpConsole, err := r.CheckConsole(ctx)
-		if err != nil {
-			return ctrl.Result{}, err
-		}
-
-		if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ConsoleMCE) {
-			if backplaneConfig.Enabled(backplanev1.ConsoleMCE) && ocpConsole {
-				result, err = r.ensureConsoleMCE(ctx, backplaneConfig)
-				if result != (ctrl.Result{}) {
-					requeue = true
-				}
-				if err != nil {
-					errs[backplanev1.ConsoleMCE] = err
-				}
-			} else {
-				result, err = r.ensureNoConsoleMCE(ctx, backplaneConfig, ocpConsole)
-				if result != (ctrl.Result{}) {
-					requeue = true
-				}
-				if err != nil {
-					errs[backplanev1.ConsoleMCE] = err
-				}
-			}
-		} else {
-			log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ConsoleMCE)
-		}
-	}
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.Discovery) {
-		if backplaneConfig.Enabled(backplanev1.Discovery) {
-			result, err = r.ensureDiscovery(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.Discovery] = err
-			}
-		} else {
-			result, err = r.ensureNoDiscovery(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.Discovery] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.Discovery)
-	}
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.Hive) {
-		if backplaneConfig.Enabled(backplanev1.Hive) {
-			result, err = r.ensureHive(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.Hive] = err
-			}
-		} else {
-			result, err = r.ensureNoHive(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.Hive] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.Hive)
-	}
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.AssistedService) {
-		if backplaneConfig.Enabled(backplanev1.AssistedService) {
-			result, err = r.ensureAssistedService(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.AssistedService] = err
-			}
-		} else {
-			result, err = r.ensureNoAssistedService(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.AssistedService] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.AssistedService)
-	}
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterLifecycle) {
-		if backplaneConfig.Enabled(backplanev1.ClusterLifecycle) {
-			result, err = r.ensureClusterLifecycle(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterLifecycle] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterLifecycle(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterLifecycle] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ClusterLifecycle)
-	}
-
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterManager) {
-		if backplaneConfig.Enabled(backplanev1.ClusterManager) {
-			result, err = r.ensureClusterManager(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterManager] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterManager(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterManager] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ClusterManager)
+		return ctrl.Result{}, err
 	}
 
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterPermission) {
-		if backplaneConfig.Enabled(backplanev1.ClusterPermission) {
-			result, err = r.ensureClusterPermission(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterPermission] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterPermission(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterPermission] = err
-			}
+	install := []Component{}
+	teardown := []Component{}
+	installed := map[string]bool{}
+	blockedOn := map[string][]string{}
+	for _, component := range components {
+		if !component.IsSupported() {
+			r.StatusManager.ClearFailure(component.GetName())
+			continue
 		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ClusterPermission)
-	}
 
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ServerFoundation) {
-		if backplaneConfig.Enabled(backplanev1.ServerFoundation) {
-			result, err = r.ensureServerFoundation(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ServerFoundation] = err
-			}
-		} else {
-			result, err = r.ensureNoServerFoundation(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ServerFoundation] = err
+		if managed := r.externallyManagedNames(backplaneConfig, component); len(managed) > 0 {
+			for _, name := range managed {
+				log.Info(messages.SkippingExternallyManaged, "component", name)
 			}
+			r.StatusManager.ClearFailure(component.GetName())
+			continue
 		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ServerFoundation)
-	}
 
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterProxyAddon) {
-		if backplaneConfig.Enabled(backplanev1.ClusterProxyAddon) && foundation.CanInstallAddons(ctx, r.Client) {
-			result, err = r.ensureClusterProxyAddon(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterProxyAddon] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterProxyAddon(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterProxyAddon] = err
+		if backplaneConfig.Enabled(component.GetName()) {
+			if unmet := r.unmetDependencies(ctx, backplaneConfig, component, installed); len(unmet) > 0 {
+				blockedOn[component.GetName()] = unmet
+				teardown = append(teardown, component)
+				continue
 			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ClusterProxyAddon)
-	}
 
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterAPI) {
-		if backplaneConfig.Enabled(backplanev1.ClusterAPI) {
-			result, err = r.ensureClusterAPI(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
+			// A component the InternalEngineComponent controller failed to reconcile keeps its backoff
+			r.adoptComponentFailure(ctx, backplaneConfig, component.GetName())
+			// A component in backoff is left installed and only requeued for its retry
+			if _, ok := r.backoffRemaining(component.GetName()); ok {
+				installed[component.GetName()] = true
+				install = append(install, component)
+				continue
 			}
+			// A component whose prerequisites cannot be checked is failed and retried with backoff
+			canInstall, err := component.CanInstall(ctx, r, backplaneConfig)
 			if err != nil {
-				errs[backplanev1.ClusterAPI] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterAPI(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
+				r.recordFailure(component.GetName(), err)
+				errs[component.GetName()] = err
+				continue
 			}
-			if err != nil {
-				errs[backplanev1.ClusterAPI] = err
+			if canInstall {
+				installed[component.GetName()] = true
+				install = append(install, component)
+				continue
 			}
 		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ClusterAPI)
+		teardown = append(teardown, component)
 	}
 
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterAPIProviderAWS) {
-		if backplaneConfig.Enabled(backplanev1.ClusterAPIProviderAWS) {
-			result, err = r.ensureClusterAPIProviderAWS(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterAPIProviderAWS] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterAPIProviderAWS(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterAPIProviderAWS] = err
-			}
+	// Remove components before the components they depend on, then install in dependency order.
+	// Components that do not depend on each other are reconciled in parallel, and a failing
+	// component is retried with backoff without holding back the others.
+	workers := componentConcurrency()
+	dependents := map[string][]string{}
+	for _, component := range teardown {
+		for _, dep := range component.GetDependencies() {
+			dependents[dep] = append(dependents[dep], component.GetName())
 		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ClusterAPIProviderAWS)
 	}
+	slices.Reverse(teardown)
+	teardownRequeue, teardownErrs := runComponents(teardown, workers,
+		func(component Component) []string { return dependents[component.GetName()] },
+		func(component Component) (ctrl.Result, error) {
+			unlock := r.componentLocks.lock(component.GetName())
+			defer unlock()
 
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterAPIProviderAzurePreview) {
-		if backplaneConfig.Enabled(backplanev1.ClusterAPIProviderAzurePreview) {
-			result, err = r.ensureClusterAPIProviderAzure(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterAPIProviderAzurePreview] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterAPIProviderAzure(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterAPIProviderAzurePreview] = err
+			result, err := r.applyComponent(ctx, component.GetName(), func(ctx context.Context) (ctrl.Result, error) {
+				return component.Disable(ctx, r, backplaneConfig)
+			})
+			if unmet, ok := blockedOn[component.GetName()]; ok {
+				log.Info("Component is blocked on its dependencies", "component", component.GetName(),
+					"dependencies", unmet)
+				r.StatusManager.AddComponent(status.NewBlockedStatus(
+					types.NamespacedName{Name: component.GetName(), Namespace: backplaneConfig.Spec.TargetNamespace},
+					unmet))
 			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component", backplanev1.ClusterAPIProviderAzurePreview)
-	}
+			return result, err
+		})
 
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterAPIProviderMetal) {
-		if backplaneConfig.Enabled(backplanev1.ClusterAPIProviderMetal) {
-			result, err = r.ensureClusterAPIProviderMetal(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterAPIProviderMetal] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterAPIProviderMetal(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterAPIProviderMetal] = err
-			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component",
-			backplanev1.ClusterAPIProviderMetal)
-	}
+	installRequeue, installErrs := runComponents(install, workers, Component.GetDependencies,
+		func(component Component) (ctrl.Result, error) {
+			unlock := r.componentLocks.lock(component.GetName())
+			defer unlock()
 
-	if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterAPIProviderOA) {
-		if backplaneConfig.Enabled(backplanev1.ClusterAPIProviderOA) {
-			result, err = r.ensureClusterAPIProviderOA(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterAPIProviderOA] = err
-			}
-		} else {
-			result, err = r.ensureNoClusterAPIProviderOA(ctx, backplaneConfig)
-			if result != (ctrl.Result{}) {
-				requeue = true
-			}
-			if err != nil {
-				errs[backplanev1.ClusterAPIProviderOA] = err
+			// Healthy components are applied by the InternalEngineComponent controller
+			if iec, ok := r.handedOff(ctx, backplaneConfig, component); ok {
+				log.V(1).Info("Component is reconciled by the InternalEngineComponent controller",
+					"component", component.GetName())
+				r.reportHandedOff(backplaneConfig, component, iec)
+				return ctrl.Result{}, nil
 			}
-		}
-	} else {
-		log.Info(messages.SkippingExternallyManaged, "component",
-			backplanev1.ClusterAPIProviderOA)
-	}
 
-	if utils.DeployOnOCP() {
-		if !r.isComponentExternallyManaged(backplaneConfig, backplanev1.MaestroPreview) &&
-			!r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterManager) {
-			if backplaneConfig.Enabled(backplanev1.MaestroPreview) {
-				result, err = r.ensureMaestro(ctx, backplaneConfig)
-				if result != (ctrl.Result{}) {
-					requeue = true
-				}
-				if err != nil {
-					errs[backplanev1.MaestroPreview] = err
-				}
-			} else {
-				result, err = r.ensureNoMaestro(ctx, backplaneConfig)
-				if result != (ctrl.Result{}) {
-					requeue = true
-				}
-				if err != nil {
-					errs[backplanev1.MaestroPreview] = err
-				}
-			}
-		} else {
-			if r.isComponentExternallyManaged(backplaneConfig, backplanev1.MaestroPreview) {
-				log.Info(messages.SkippingExternallyManaged, "component",
-					backplanev1.MaestroPreview)
-			}
-			if r.isComponentExternallyManaged(backplaneConfig, backplanev1.ClusterManager) {
-				log.Info(messages.SkippingExternallyManaged, "component",
-					backplanev1.ClusterManager)
+			result, err := r.applyComponent(ctx, component.GetName(), func(ctx context.Context) (ctrl.Result, error) {
+				return component.Enable(ctx, r, backplaneConfig)
+			})
+			// Record the outcome on the InternalEngineComponent of the component
+			if iecErr := r.updateInternalEngineComponent(withInventoryComponent(ctx, component.GetName()),
+				backplaneConfig, component.GetName(), result, err); iecErr != nil && err == nil {
+				return result, iecErr
 			}
-		}
-	}
+			return result, err
+		})
 
-	if backplaneConfig.Enabled(backplanev1.LocalCluster) {
-		result, err := r.ensureLocalCluster(ctx, backplaneConfig)
-		if result != (ctrl.Result{}) {
-			requeue = true
-		}
-		if err != nil {
-			errs[backplanev1.LocalCluster] = err
-		}
-	} else {
-		result, err := r.ensureNoLocalCluster(ctx, backplaneConfig)
-		if result != (ctrl.Result{}) {
-			requeue = true
-		}
-		if err != nil {
-			errs[backplanev1.LocalCluster] = err
-		}
-	}
+	requeue := teardownRequeue || installRequeue
+	maps.Copy(errs, teardownErrs)
+	maps.Copy(errs, installErrs)
 
 	if len(errs) > 0 {
 		errorMessages := []string{}
@@ -1543,6 +1170,44 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	return ctrl.Result{}, nil
 }
 
+/*
+unmetDependencies returns the dependencies of the component that are neither installed in
+this reconcile nor externally managed, or that do not serve their dependents yet.
+*/
+func (r *MultiClusterEngineReconciler) unmetDependencies(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, component Component, installed map[string]bool) []string {
+	unmet := []string{}
+	for _, dep := range component.GetDependencies() {
+		if !installed[dep] && !r.isComponentExternallyManaged(backplaneConfig, dep) {
+			unmet = append(unmet, dep)
+			continue
+		}
+		if c, ok := lookupComponent(dep); ok {
+			if ready, err := c.IsReady(ctx, r, backplaneConfig); err != nil || !ready {
+				log.V(1).Info("Dependency is not ready", "component", component.GetName(), "dependency", dep,
+					"error", err)
+				unmet = append(unmet, dep)
+			}
+		}
+	}
+	return unmet
+}
+
+/*
+externallyManagedNames returns the names that stop the operator from reconciling the
+component because they are listed in the externally-managed annotation.
+*/
+func (r *MultiClusterEngineReconciler) externallyManagedNames(backplaneConfig *backplanev1.MultiClusterEngine,
+	component Component) []string {
+	managed := []string{}
+	for _, name := range component.GetExternalManagementNames() {
+		if r.isComponentExternallyManaged(backplaneConfig, name) {
+			managed = append(managed, name)
+		}
+	}
+	return managed
+}
+
 /*
 getComponentConfig searches for a component configuration in the provided list
 by component name. It returns the configuration and a boolean indicating
@@ -1603,6 +1268,41 @@ func (r *MultiClusterEngineReconciler) isComponentExternallyManaged(mce *backpla
 	return false
 }
 
+/*
+getCRDSkipDirectories returns the CRD directories that are not rendered for the MultiClusterEngine:
+those of externally managed and disabled components, and the variants for the other platform.
+*/
+func (r *MultiClusterEngineReconciler) getCRDSkipDirectories(mce *backplanev1.MultiClusterEngine) []string {
+	// Collect CRD directories to exclude from rendering
+	// 1. Externally managed components - CRDs owned by external operators
+	externallyManagedCRDDirs := r.getExternallyManagedCRDSkipDirectories(mce)
+
+	// 2. Disabled components - prevents CRD updates for disabled features
+	disabledComponentCRDDirs := r.getDisabledComponentCRDSkipDirectories(mce)
+
+	// 3. Platform-specific variants - ensures only the appropriate variant (OCP or K8s) is deployed
+	platformSpecificCRDDirs := r.getPlatformSpecificCRDSkipDirectories()
+
+	// Merge all skip lists and deduplicate using a map
+	dirMap := make(map[string]bool)
+	for _, dir := range externallyManagedCRDDirs {
+		dirMap[dir] = true
+	}
+	for _, dir := range disabledComponentCRDDirs {
+		dirMap[dir] = true
+	}
+	for _, dir := range platformSpecificCRDDirs {
+		dirMap[dir] = true
+	}
+
+	// Convert merged skip list back to slice for rendering
+	mergedSkipCRDDirs := []string{}
+	for dir := range dirMap {
+		mergedSkipCRDDirs = append(mergedSkipCRDDirs, dir)
+	}
+	return mergedSkipCRDDirs
+}
+
 /*
 getExternallyManagedCRDSkipDirectories returns a list of CRD directory names to skip
 for components marked as externally managed.
@@ -1631,6 +1331,9 @@ func (r *MultiClusterEngineReconciler) getExternallyManagedCRDSkipDirectories(mc
 	for _, comp := range components {
 		// Get all CRD directory variants for this component
 		crdDirs := utils.ComponentCRDDirectories(comp)
+		if c, ok := lookupComponent(comp); ok {
+			crdDirs = c.GetCRDDirs()
+		}
 		for _, crdDir := range crdDirs {
 			// Only add if not already in the list
 			if !dirMap[crdDir] {
@@ -1649,28 +1352,23 @@ for components that are disabled. This prevents CRD updates for disabled compone
 without deleting the CRDs.
 */
 func (r *MultiClusterEngineReconciler) getDisabledComponentCRDSkipDirectories(mce *backplanev1.MultiClusterEngine) []string {
-	// Build list of CRD directories to skip for disabled cluster-api components only
+	// Build list of CRD directories to skip for disabled components that opt into it
 	skipDirs := []string{}
 	dirMap := make(map[string]bool) // Track unique directories
 
-	// Only check CAPI components
-	componentsToCheck := []string{
-		backplanev1.ClusterAPI,
-		backplanev1.ClusterAPIProviderAWS,
-		backplanev1.ClusterAPIProviderAzurePreview,
-		backplanev1.ClusterAPIProviderMetal,
-		backplanev1.ClusterAPIProviderOA,
-	}
+	for _, component := range registeredComponents() {
+		c, ok := component.(*chartComponent)
+		if !ok || !c.skipCRDsWhenDisabled {
+			continue
+		}
 
-	for _, comp := range componentsToCheck {
 		// Skip if component is enabled or externally managed
-		if mce.Enabled(comp) || r.isComponentExternallyManaged(mce, comp) {
+		if mce.Enabled(c.GetName()) || r.isComponentExternallyManaged(mce, c.GetName()) {
 			continue
 		}
 
 		// Component is disabled - add all its CRD directory variants to skip list
-		crdDirs := utils.ComponentCRDDirectories(comp)
-		for _, crdDir := range crdDirs {
+		for _, crdDir := range c.GetCRDDirs() {
 			// Only add if not already in the list
 			if !dirMap[crdDir] {
 				skipDirs = append(skipDirs, crdDir)
@@ -1724,20 +1422,14 @@ func (r *MultiClusterEngineReconciler) checkExternallyManagedComponents(mce *bac
 	annotations := mce.GetAnnotations()
 	if annotations == nil {
 		// No annotations, ensure condition is removed
-		r.StatusManager.Conditions = status.FilterOutConditionWithSubString(
-			r.StatusManager.Conditions,
-			backplanev1.MultiClusterEngineComponentsExternallyManaged,
-		)
+		r.StatusManager.RemoveCondition(backplanev1.MultiClusterEngineComponentsExternallyManaged)
 		return
 	}
 
 	managedComponents, ok := annotations[utils.AnnotationExternallyManaged]
 	if !ok || managedComponents == "" {
 		// Annotation not present or empty, ensure condition is removed
-		r.StatusManager.Conditions = status.FilterOutConditionWithSubString(
-			r.StatusManager.Conditions,
-			backplanev1.MultiClusterEngineComponentsExternallyManaged,
-		)
+		r.StatusManager.RemoveCondition(backplanev1.MultiClusterEngineComponentsExternallyManaged)
 		return
 	}
 
@@ -1746,19 +1438,13 @@ func (r *MultiClusterEngineReconciler) checkExternallyManagedComponents(mce *bac
 	if err := json.Unmarshal([]byte(managedComponents), &components); err != nil {
 		log.Error(err, "Failed to parse externally managed components annotation")
 		// On parse error, remove the condition to avoid showing stale data
-		r.StatusManager.Conditions = status.FilterOutConditionWithSubString(
-			r.StatusManager.Conditions,
-			backplanev1.MultiClusterEngineComponentsExternallyManaged,
-		)
+		r.StatusManager.RemoveCondition(backplanev1.MultiClusterEngineComponentsExternallyManaged)
 		return
 	}
 
 	if len(components) == 0 {
 		// Empty component list, ensure condition is removed
-		r.StatusManager.Conditions = status.FilterOutConditionWithSubString(
-			r.StatusManager.Conditions,
-			backplanev1.MultiClusterEngineComponentsExternallyManaged,
-		)
+		r.StatusManager.RemoveCondition(backplanev1.MultiClusterEngineComponentsExternallyManaged)
 		return
 	}
 
@@ -1783,10 +1469,7 @@ func (r *MultiClusterEngineReconciler) checkExternallyManagedComponents(mce *bac
 	// If no valid components after filtering, remove the condition if it exists
 	if len(validComponents) == 0 {
 		// Remove the ComponentsExternallyManaged condition since no components are externally managed
-		r.StatusManager.Conditions = status.FilterOutConditionWithSubString(
-			r.StatusManager.Conditions,
-			backplanev1.MultiClusterEngineComponentsExternallyManaged,
-		)
+		r.StatusManager.RemoveCondition(backplanev1.MultiClusterEngineComponentsExternallyManaged)
 		return
 	}
 
@@ -1816,7 +1499,8 @@ func (r *MultiClusterEngineReconciler) checkExternallyManagedComponents(mce *bac
 
 /*
 applyEnvConfig updates the specified container in the provided template with
-new environment variables. Logs errors if encountered during retrieval or update operations.
+new environment variables. A variable the container already defines is replaced, other variables are
+appended. Logs errors if encountered during retrieval or update operations.
 */
 func (r *MultiClusterEngineReconciler) applyEnvConfig(template *unstructured.Unstructured, containerName string,
 	envConfigs []backplanev1.EnvConfig) error {
@@ -1824,52 +1508,340 @@ func (r *MultiClusterEngineReconciler) applyEnvConfig(template *unstructured.Uns
 	containers, found, err := unstructured.NestedSlice(template.Object, "spec", "template", "spec", "containers")
 	if err != nil || !found {
 		log.Error(err, "Failed to get containers from template", "Kind", template.GetKind(), "Name", template.GetName())
-		return err
+		return err
+	}
+
+	for i, container := range containers {
+		// We need to cast the container to a map of string interfaces to access the container fields.
+		containerMap := container.(map[string]interface{})
+
+		if containerMap["name"] == containerName {
+			existingEnv, _, _ := unstructured.NestedSlice(containerMap, "env")
+			for _, envConfig := range envConfigs {
+				envVar, err := envVarOf(envConfig)
+				if err != nil {
+					log.Error(err, "Failed to convert environment variable", "Container", containerName,
+						"Name", envConfig.Name)
+					return err
+				}
+
+				replaced := false
+				for j, existing := range existingEnv {
+					if existingMap, ok := existing.(map[string]interface{}); ok && existingMap["name"] == envConfig.Name {
+						existingEnv[j] = envVar
+						replaced = true
+						break
+					}
+				}
+				if !replaced {
+					existingEnv = append(existingEnv, envVar)
+				}
+			}
+
+			if err := unstructured.SetNestedSlice(containerMap, existingEnv, "env"); err != nil {
+				log.Error(err, "Failed to set environment variable", "Container", containerName)
+				return err
+
+			} else {
+				containers[i] = containerMap
+			}
+			break
+		}
+	}
+
+	if err = unstructured.SetNestedSlice(template.Object, containers, "spec", "template", "spec",
+		"containers"); err != nil {
+		log.Error(err, "Failed to set containers in template", "Template", template.GetName())
+		return err
+	}
+
+	return nil
+}
+
+// envVarOf returns the container environment variable of the override, in its unstructured form.
+func envVarOf(envConfig backplanev1.EnvConfig) (map[string]interface{}, error) {
+	envVar := corev1.EnvVar{Name: envConfig.Name, Value: envConfig.Value}
+	if source := envConfig.ValueFrom; source != nil {
+		envVar.ValueFrom = &corev1.EnvVarSource{
+			SecretKeyRef:    source.SecretKeyRef,
+			ConfigMapKeyRef: source.ConfigMapKeyRef,
+			FieldRef:        source.FieldRef,
+		}
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(&envVar)
+}
+
+/*
+applyResourcesConfig overrides the resource requests and limits of the specified container in the provided
+template with the ones listed in resources, keeping the requests and limits that are not listed.
+*/
+func (r *MultiClusterEngineReconciler) applyResourcesConfig(template *unstructured.Unstructured, containerName string,
+	resources corev1.ResourceRequirements) error {
+
+	if len(resources.Requests) == 0 && len(resources.Limits) == 0 {
+		return nil
+	}
+
+	containers, found, err := unstructured.NestedSlice(template.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		log.Error(err, "Failed to get containers from template", "Kind", template.GetKind(), "Name", template.GetName())
+		return err
+	}
+
+	for i, container := range containers {
+		containerMap := container.(map[string]interface{})
+		if containerMap["name"] != containerName {
+			continue
+		}
+
+		for field, overrides := range map[string]corev1.ResourceList{"requests": resources.Requests,
+			"limits": resources.Limits} {
+			if len(overrides) == 0 {
+				continue
+			}
+			existing, _, _ := unstructured.NestedMap(containerMap, "resources", field)
+			if existing == nil {
+				existing = map[string]interface{}{}
+			}
+			for name, quantity := range overrides {
+				existing[string(name)] = quantity.String()
+			}
+			if err := unstructured.SetNestedMap(containerMap, existing, "resources", field); err != nil {
+				log.Error(err, "Failed to set container resources", "Container", containerName)
+				return err
+			}
+		}
+		containers[i] = containerMap
+		break
+	}
+
+	if err = unstructured.SetNestedSlice(template.Object, containers, "spec", "template", "spec",
+		"containers"); err != nil {
+		log.Error(err, "Failed to set containers in template", "Template", template.GetName())
+		return err
+	}
+
+	return nil
+}
+
+/*
+applyContainerConfig overrides the image and image pull policy of the container named in the provided
+container configuration and appends its arguments to the arguments of the container. It reports whether
+any of these overrides is set and the container is found in the template.
+*/
+func (r *MultiClusterEngineReconciler) applyContainerConfig(template *unstructured.Unstructured,
+	containerConfig backplanev1.ContainerConfig) (bool, error) {
+
+	if containerConfig.Image == "" && containerConfig.ImagePullPolicy == "" && len(containerConfig.Args) == 0 {
+		return false, nil
+	}
+
+	containers, found, err := unstructured.NestedSlice(template.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		log.Error(err, "Failed to get containers from template", "Kind", template.GetKind(), "Name", template.GetName())
+		return false, err
 	}
 
+	applied := false
 	for i, container := range containers {
-		// We need to cast the container to a map of string interfaces to access the container fields.
 		containerMap := container.(map[string]interface{})
+		if containerMap["name"] != containerConfig.Name {
+			continue
+		}
 
-		if containerMap["name"] == containerName {
-			existingEnv, _, _ := unstructured.NestedSlice(containerMap, "env")
-			for _, envConfig := range envConfigs {
-				envVar := map[string]interface{}{
-					"name":  envConfig.Name,
-					"value": envConfig.Value,
-				}
-				existingEnv = append(existingEnv, envVar)
-			}
-
-			if err := unstructured.SetNestedSlice(containerMap, existingEnv, "env"); err != nil {
-				log.Error(err, "Failed to set environment variable", "Container", containerName)
-				return err
-
-			} else {
-				containers[i] = containerMap
+		if containerConfig.Image != "" {
+			containerMap["image"] = containerConfig.Image
+		}
+		if containerConfig.ImagePullPolicy != "" {
+			containerMap["imagePullPolicy"] = string(containerConfig.ImagePullPolicy)
+		}
+		if len(containerConfig.Args) > 0 {
+			args, _, _ := unstructured.NestedStringSlice(containerMap, "args")
+			if err := unstructured.SetNestedStringSlice(containerMap, append(args, containerConfig.Args...),
+				"args"); err != nil {
+				log.Error(err, "Failed to set container arguments", "Container", containerConfig.Name)
+				return false, err
 			}
-			break
 		}
+		containers[i] = containerMap
+		applied = true
+		break
 	}
 
 	if err = unstructured.SetNestedSlice(template.Object, containers, "spec", "template", "spec",
 		"containers"); err != nil {
 		log.Error(err, "Failed to set containers in template", "Template", template.GetName())
-		return err
+		return false, err
+	}
+
+	return applied, nil
+}
+
+/*
+applyPodMetadata adds the labels and annotations to the pod template of the provided deployment template.
+Annotations replace the annotations of the chart with the same key, while the labels of the chart are kept
+since selectors rely on them.
+*/
+func (r *MultiClusterEngineReconciler) applyPodMetadata(template *unstructured.Unstructured,
+	labels, annotations map[string]string) error {
+
+	for field, values := range map[string]map[string]string{"labels": labels, "annotations": annotations} {
+		if len(values) == 0 {
+			continue
+		}
+		existing, _, err := unstructured.NestedStringMap(template.Object, "spec", "template", "metadata", field)
+		if err != nil {
+			log.Error(err, "Failed to get pod metadata from template", "Field", field, "Template", template.GetName())
+			return err
+		}
+		if existing == nil {
+			existing = map[string]string{}
+		}
+		for key, value := range values {
+			if _, set := existing[key]; set && field == "labels" {
+				continue
+			}
+			existing[key] = value
+		}
+		if err := unstructured.SetNestedStringMap(template.Object, existing, "spec", "template", "metadata",
+			field); err != nil {
+			log.Error(err, "Failed to set pod metadata in template", "Field", field, "Template", template.GetName())
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeStringMaps returns the entries of both maps, with the entries of override replacing those of base.
+func mergeStringMaps(base, override map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(override))
+	maps.Copy(merged, base)
+	maps.Copy(merged, override)
+	return merged
+}
+
+/*
+applySchedulingConfig overrides the node selector, tolerations, affinity and topology spread constraints
+of the pods of the provided deployment template with the ones set in the component configuration.
+*/
+func (r *MultiClusterEngineReconciler) applySchedulingConfig(template *unstructured.Unstructured,
+	componentConfig backplanev1.ComponentConfig) error {
+
+	podSpec := []string{"spec", "template", "spec"}
+	if componentConfig.NodeSelector != nil {
+		if err := unstructured.SetNestedStringMap(template.Object, componentConfig.NodeSelector,
+			append(podSpec, "nodeSelector")...); err != nil {
+			log.Error(err, "Failed to set node selector", "Template", template.GetName())
+			return err
+		}
+	}
+
+	var tolerations, topologySpreadConstraints []interface{}
+	for i := range componentConfig.Tolerations {
+		toleration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&componentConfig.Tolerations[i])
+		if err != nil {
+			return err
+		}
+		tolerations = append(tolerations, toleration)
+	}
+	for i := range componentConfig.TopologySpreadConstraints {
+		constraint, err := runtime.DefaultUnstructuredConverter.ToUnstructured(
+			&componentConfig.TopologySpreadConstraints[i])
+		if err != nil {
+			return err
+		}
+		topologySpreadConstraints = append(topologySpreadConstraints, constraint)
+	}
+
+	for field, value := range map[string][]interface{}{"tolerations": tolerations,
+		"topologySpreadConstraints": topologySpreadConstraints} {
+		if len(value) == 0 {
+			continue
+		}
+		if err := unstructured.SetNestedSlice(template.Object, value, append(podSpec, field)...); err != nil {
+			log.Error(err, "Failed to set scheduling field", "Field", field, "Template", template.GetName())
+			return err
+		}
 	}
 
+	if componentConfig.Affinity != nil {
+		override, err := runtime.DefaultUnstructuredConverter.ToUnstructured(componentConfig.Affinity)
+		if err != nil {
+			return err
+		}
+		affinity, _, err := unstructured.NestedMap(template.Object, append(podSpec, "affinity")...)
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedMap(template.Object, mergeAffinity(affinity, override),
+			append(podSpec, "affinity")...); err != nil {
+			log.Error(err, "Failed to set affinity", "Template", template.GetName())
+			return err
+		}
+	}
 	return nil
 }
 
-func (r *MultiClusterEngineReconciler) applyComponentDeploymentOverrides(mce *backplanev1.MultiClusterEngine,
-	templates []*unstructured.Unstructured, component string) (ctrl.Result, error) {
+/*
+mergeAffinity merges the affinity override into the affinity of a Deployment, so that the pod anti-affinity
+added for high availability is kept. The scheduling terms of the override are added to those of the
+Deployment, and the required node selector of the override replaces that of the Deployment.
+*/
+func mergeAffinity(affinity, override map[string]interface{}) map[string]interface{} {
+	if affinity == nil {
+		affinity = map[string]interface{}{}
+	}
+	for kind, rules := range override {
+		overrideRules, ok := rules.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existing, ok := affinity[kind].(map[string]interface{})
+		if !ok {
+			affinity[kind] = overrideRules
+			continue
+		}
+		for field, value := range overrideRules {
+			terms, isTerms := value.([]interface{})
+			existingTerms, hasTerms := existing[field].([]interface{})
+			if isTerms && hasTerms {
+				existing[field] = append(existingTerms, terms...)
+			} else {
+				existing[field] = value
+			}
+		}
+	}
+	return affinity
+}
+
+/*
+applyComponentDeploymentOverrides applies the configuration overrides of the component to its rendered
+templates: the pod labels and annotations, scheduling and replicas of its deployments, the environment
+variables, resources, images and arguments of their containers, then its patches. The image, image pull
+policy and argument overrides applied, and the footprint of the deployments once overridden, are recorded
+in the status, except in plan mode.
+*/
+func (r *MultiClusterEngineReconciler) applyComponentDeploymentOverrides(ctx context.Context,
+	mce *backplanev1.MultiClusterEngine, templates []*unstructured.Unstructured, component string) (ctrl.Result, error) {
 
 	// Check if the component has overrides available
 	componentConfig, found := r.getComponentConfig(mce.Spec.Overrides.Components, component)
 
+	// The pod labels and annotations of the MultiClusterEngine apply to components without overrides too
+	podLabels := mergeStringMaps(mce.Spec.PodLabels, componentConfig.PodLabels)
+	podAnnotations := mergeStringMaps(mce.Spec.PodAnnotations, componentConfig.PodAnnotations)
+	for _, template := range templates {
+		if template.GetKind() != "Deployment" {
+			continue
+		}
+		if err := r.applyPodMetadata(template, podLabels, podAnnotations); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if !found {
 		log.V(2).Info("No component config found", "Component", component)
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.recordFootprint(ctx, component, templates)
 	}
 
 	for _, template := range templates {
@@ -1878,6 +1850,10 @@ func (r *MultiClusterEngineReconciler) applyComponentDeploymentOverrides(mce *ba
 			continue // Skip if the template is not of a deployment
 		}
 
+		if err := r.applySchedulingConfig(template, componentConfig); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		deploymentConfig, found := r.getDeploymentConfig(
 			componentConfig.ConfigOverrides.Deployments, template.GetName())
 
@@ -1887,15 +1863,62 @@ func (r *MultiClusterEngineReconciler) applyComponentDeploymentOverrides(mce *ba
 		}
 
 		log.V(2).Info("Applying deployment overrides for template", "Name", template.GetName())
-		// Apply environment variable overrides for each container
+		if deploymentConfig.Replicas != nil {
+			if err := unstructured.SetNestedField(template.Object, int64(*deploymentConfig.Replicas),
+				"spec", "replicas"); err != nil {
+				log.Error(err, "Failed to set replicas", "Template", template.GetName())
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Apply environment variable, resource, image and argument overrides for each container
 		for _, container := range deploymentConfig.Containers {
 			if err := r.applyEnvConfig(template, container.Name, container.Env); err != nil {
 				return ctrl.Result{}, err
 			}
+			if err := r.applyResourcesConfig(template, container.Name, container.Resources); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			applied, err := r.applyContainerConfig(template, container)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if _, planning := planFromContext(ctx); applied && r.StatusManager != nil && !planning {
+				r.StatusManager.AddContainerOverride(backplanev1.ContainerOverride{
+					Component:       component,
+					Deployment:      template.GetName(),
+					Container:       container.Name,
+					Image:           container.Image,
+					ImagePullPolicy: container.ImagePullPolicy,
+					Args:            container.Args,
+				})
+			}
 		}
 	}
 
-	return ctrl.Result{}, nil
+	if err := r.applyComponentPatches(ctx, mce, templates, componentConfig); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.recordFootprint(ctx, component, templates)
+}
+
+/*
+recordFootprint records the replicas, containers and resources of the Deployments of the component in the
+status. Nothing is recorded in plan mode, as the templates are not applied.
+*/
+func (r *MultiClusterEngineReconciler) recordFootprint(ctx context.Context, component string,
+	templates []*unstructured.Unstructured) error {
+	if _, planning := planFromContext(ctx); r.StatusManager == nil || planning {
+		return nil
+	}
+	footprint, err := renderer.Footprint(component, templates)
+	if err != nil {
+		log.Error(err, "Failed to compute the footprint of the component", "Component", component)
+		return err
+	}
+	r.StatusManager.AddComponentFootprint(footprint)
+	return nil
 }
 
 func (r *MultiClusterEngineReconciler) ApplyControllerReference(backplaneConfig *backplanev1.MultiClusterEngine,
@@ -1992,8 +2015,17 @@ func (r *MultiClusterEngineReconciler) getAdoptionPolicy(mce *backplanev1.MultiC
 func (r *MultiClusterEngineReconciler) applyTemplate(ctx context.Context,
 	backplaneConfig *backplanev1.MultiClusterEngine, template *unstructured.Unstructured) (ctrl.Result, error) {
 
+	// Resources of a component are labeled so that they are reconciled with the component
+	if component, ok := lookupComponent(componentFromContext(ctx)); ok {
+		utils.AddComponentLabel(template, component.GetName())
+	}
+
 	if template.GetKind() == "APIService" {
-		return r.ensureUnstructuredResource(ctx, backplaneConfig, template)
+		result, err := r.ensureUnstructuredResource(ctx, backplaneConfig, template)
+		if err == nil {
+			recordApplied(ctx, template)
+		}
+		return result, err
 
 	} else {
 		// Check if the namespace exists if the template specifies a namespace.
@@ -2011,6 +2043,12 @@ func (r *MultiClusterEngineReconciler) applyTemplate(ctx context.Context,
 			}
 		}
 
+		// In plan mode changes are only dry-run and recorded in the plan
+		pc, planning := planFromContext(ctx)
+
+		// The hash of the rendered template is compared with the live resource to skip no-op applies
+		setTemplateHash(template)
+
 		existing := template.DeepCopy()
 		if err := r.Client.Get(ctx, types.NamespacedName{Name: existing.GetName(),
 			Namespace: existing.GetNamespace()}, existing); err != nil {
@@ -2026,31 +2064,31 @@ func (r *MultiClusterEngineReconciler) applyTemplate(ctx context.Context,
 					}
 				}
 
-				if err := r.Client.Create(ctx, template, &client.CreateOptions{}); err != nil {
+				createOpts := &client.CreateOptions{}
+				if planning {
+					createOpts.DryRun = []string{metav1.DryRunAll}
+				}
+				if err := r.Client.Create(ctx, template, createOpts); err != nil {
 					// Check if the error is because the CRD doesn't exist
 					if apierrors.IsNotFound(err) {
-						return r.logAndSetCondition(
-							err,
-							"failed to create resource -- CRD not installed",
-							template,
-							backplaneConfig,
-						)
+						return r.logApplyError(err, "failed to create resource -- CRD not installed", template)
 					}
 					if !apierrors.IsAlreadyExists(err) {
-						return r.logAndSetCondition(
-							err,
-							"failed to create resource -- Template already exists",
-							template,
-							backplaneConfig,
-						)
+						return r.logApplyError(err, "failed to create resource -- Template already exists", template)
 					}
 					// If already exists, that's fine - another reconcile may have created it
 					log.V(1).Info("Resource already exists", "Kind", template.GetKind(), "Name", template.GetName())
+				} else if planning {
+					pc.recordCreate(template)
 				} else {
 					log.Info("Creating resource", "Kind", template.GetKind(), "Name", template.GetName())
 				}
+			} else if apimeta.IsNoMatchError(err) {
+				return r.logApplyError(fmt.Errorf("kind %s of %s is not known to the cluster: %w",
+					template.GroupVersionKind(), template.GetName(), err),
+					"failed to get resource -- CRD not installed", template)
 			} else {
-				return r.logAndSetCondition(err, "failed to get resource", existing, backplaneConfig)
+				return r.logApplyError(err, "failed to get resource", existing)
 			}
 		} else {
 			// Resource exists - ensure we should manage it (adds labels to template if adopting)
@@ -2067,7 +2105,8 @@ func (r *MultiClusterEngineReconciler) applyTemplate(ctx context.Context,
 				log.Info("Warning: OPERATOR_VERSION environment variable is not set")
 			}
 
-			if !r.ensureResourceVersionAlignment(existing, desiredVersion) {
+			aligned := r.ensureResourceVersionAlignment(existing, desiredVersion)
+			if !aligned && !planning {
 				r.StatusManager.AddCondition(
 					status.NewCondition(
 						backplanev1.MultiClusterEngineProgressing, metav1.ConditionTrue,
@@ -2076,17 +2115,35 @@ func (r *MultiClusterEngineReconciler) applyTemplate(ctx context.Context,
 				)
 			}
 
-			if !utils.IsTemplateAnnotationTrue(template, utils.AnnotationEditable) {
+			// Changes between releases are upgrades rather than drift, so drift is only reported once the
+			// resource is at the target version.
+			drifted := false
+			if (aligned || desiredVersion == "") && !planning {
+				drifted = r.reportDrift(backplaneConfig, template, existing)
+			}
+
+			if !planning && !drifted && templateUnchanged(template, existing) {
+				log.V(1).Info("Resource is up to date with its template", "Kind", template.GetKind(),
+					"Name", template.GetName())
+			} else if !utils.IsTemplateAnnotationTrue(template, utils.AnnotationEditable) &&
+				!(drifted && utils.IsDriftReportOnly(backplaneConfig)) {
 				// Resource exists; use the original template for patching to avoid issues with managedFields
 				// Apply the object data.
 				force := true
-				if err := r.Client.Patch(ctx, template, client.Apply, &client.PatchOptions{
-					Force: &force, FieldManager: "backplane-operator"}); err != nil {
-					return r.logAndSetCondition(err, "failed to update resource", template, backplaneConfig)
+				patchOpts := &client.PatchOptions{Force: &force, FieldManager: "backplane-operator"}
+				if planning {
+					patchOpts.DryRun = []string{metav1.DryRunAll}
+				}
+				if err := r.Client.Patch(ctx, template, client.Apply, patchOpts); err != nil {
+					return r.logApplyError(err, "failed to update resource", template)
+				}
+				if planning && planResourceChanged(existing, template) {
+					pc.recordUpdate(template)
 				}
 			}
 		}
 	}
+	recordApplied(ctx, template)
 	return ctrl.Result{}, nil
 }
 
@@ -2116,22 +2173,17 @@ func (r *MultiClusterEngineReconciler) ensureResourceVersionAlignment(template *
 	return true // Resource is aligned with the desired version
 }
 
-func (r *MultiClusterEngineReconciler) logAndSetCondition(err error, message string,
-	template *unstructured.Unstructured, m *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+/*
+logApplyError logs a failure to apply the resource and returns it as a resourceError, so that the
+failure is recorded against the component that rendered the resource.
+*/
+func (r *MultiClusterEngineReconciler) logApplyError(err error, message string,
+	template *unstructured.Unstructured) (ctrl.Result, error) {
 
 	log.Error(err, message, "Kind", template.GetKind(), "Name", template.GetName())
-	wrappedError := pkgerrors.Wrapf(err, "%s Kind: %s Name: %s", message, template.GetName(), template.GetKind())
+	wrappedError := pkgerrors.Wrapf(err, "%s Kind: %s Name: %s", message, template.GetKind(), template.GetName())
 
-	condType := fmt.Sprintf("%v: %v (Kind:%v)", backplanev1.MultiClusterEngineComponentFailure, template.GetName(),
-		template.GetKind())
-
-	r.StatusManager.AddCondition(
-		status.NewCondition(
-			backplanev1.MultiClusterEngineConditionType(condType), metav1.ConditionTrue,
-			status.ApplyFailedReason, wrappedError.Error()),
-	)
-
-	return ctrl.Result{}, wrappedError
+	return ctrl.Result{}, &resourceError{Kind: template.GetKind(), Name: template.GetName(), err: wrappedError}
 }
 
 // deleteTemplate return true if resource does not exist and returns an error if a GET or DELETE errors unexpectedly. A false response without error
@@ -2161,6 +2213,15 @@ func (r *MultiClusterEngineReconciler) deleteTemplate(ctx context.Context,
 		return ctrl.Result{}, nil
 	}
 
+	// In plan mode the deletion is only dry-run and recorded in the plan
+	if pc, planning := planFromContext(ctx); planning {
+		if err := r.Client.Delete(ctx, template, client.DryRunAll); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		pc.recordDelete(template)
+		return ctrl.Result{}, nil
+	}
+
 	err = r.Client.Delete(ctx, template)
 	if err != nil && !apierrors.IsNotFound(err) {
 		log.Error(err, "Failed to delete template")
@@ -2524,18 +2585,29 @@ func (r *MultiClusterEngineReconciler) ensureUnstructuredResource(ctx context.Co
 		Namespace: u.GetNamespace(),
 	}, found)
 	if err != nil && apierrors.IsNotFound(err) {
+		setTemplateHash(u)
+
 		// Ensure the controller reference is applied to the resource before proceeding.
 		if err := r.ApplyControllerReference(bpc, u); err != nil {
 			return ctrl.Result{}, err
 		}
 
 		// Resource doesn't exist so create it
-		err := r.Client.Create(ctx, u)
+		createOpts := &client.CreateOptions{}
+		pc, planning := planFromContext(ctx)
+		if planning {
+			createOpts.DryRun = []string{metav1.DryRunAll}
+		}
+		err := r.Client.Create(ctx, u, createOpts)
 		if err != nil {
 			// Creation failed
 			r.Log.Error(err, "Failed to create new instance")
 			return ctrl.Result{}, err
 		}
+		if planning {
+			pc.recordCreate(u)
+			return ctrl.Result{}, nil
+		}
 		// Creation was successful
 		r.Log.Info("Creating resource", "Name", u.GetName(), "Kind", u.GetKind())
 		return ctrl.Result{}, nil
@@ -2582,6 +2654,63 @@ func setDefaultAnnotations(m *backplanev1.MultiClusterEngine) bool {
 
 func (r *MultiClusterEngineReconciler) setDefaults(ctx context.Context, m *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
 
+	updateNecessary := setStaticDefaults(m)
+
+	if utils.DeployOnOCP() {
+		// Set and store cluster Ingress domain for use later
+		clusterIngressDomain, err := r.getClusterIngressDomain(ctx, m)
+		if err != nil {
+			return ctrl.Result{}, pkgerrors.Wrapf(err, "failed to detect cluster ingress domain")
+		}
+
+		// Set OCP version as env var, so that charts can render this value
+		os.Setenv("ACM_CLUSTER_INGRESS_DOMAIN", clusterIngressDomain)
+
+		consoleURL, err := r.getConsoleURL(ctx)
+		if err != nil {
+			log.Info("Failed to detect console URL, fleet-navigation link may be empty", "error", err)
+		} else if err := os.Setenv("ACM_HUB_CONSOLE_URL", consoleURL); err != nil {
+			return ctrl.Result{}, pkgerrors.Wrapf(err, "failed to set ACM_HUB_CONSOLE_URL")
+		}
+
+		// If OCP 4.10+ then set then enable the MCE console. Else ensure it is disabled
+		currentClusterVersion, err := r.getClusterVersion(ctx)
+		if err != nil {
+			return ctrl.Result{}, pkgerrors.Wrapf(err, "failed to detect clusterversion")
+		}
+
+		// Set OCP version as env var, so that charts can render this value
+		os.Setenv("ACM_HUB_OCP_VERSION", currentClusterVersion)
+
+		consoleUpdated, err := setConsoleMCEDefault(m, currentClusterVersion)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if consoleUpdated {
+			updateNecessary = true
+		}
+	}
+
+	// Apply defaults to server
+	if updateNecessary {
+		log.Info("Setting defaults")
+		err := r.Client.Update(ctx, m)
+		if err != nil {
+			log.Error(err, "Failed to update MultiClusterEngine")
+			return ctrl.Result{}, err
+		}
+		log.Info("MultiClusterEngine successfully updated")
+		return ctrl.Result{Requeue: true}, nil
+	} else {
+		return ctrl.Result{}, nil
+	}
+}
+
+/*
+setStaticDefaults sets the defaults of the MultiClusterEngine that do not depend on the cluster, and
+reports whether the MultiClusterEngine was changed.
+*/
+func setStaticDefaults(m *backplanev1.MultiClusterEngine) bool {
 	updateNecessary := false
 	if !utils.AvailabilityConfigIsValid(m.Spec.AvailabilityConfig) {
 		m.Spec.AvailabilityConfig = backplanev1.HAHigh
@@ -2627,74 +2756,41 @@ func (r *MultiClusterEngineReconciler) setDefaults(ctx context.Context, m *backp
 		}
 	}
 
-	if utils.DeployOnOCP() {
-		// Set and store cluster Ingress domain for use later
-		clusterIngressDomain, err := r.getClusterIngressDomain(ctx, m)
-		if err != nil {
-			return ctrl.Result{}, pkgerrors.Wrapf(err, "failed to detect cluster ingress domain")
-		}
-
-		// Set OCP version as env var, so that charts can render this value
-		os.Setenv("ACM_CLUSTER_INGRESS_DOMAIN", clusterIngressDomain)
-
-		consoleURL, err := r.getConsoleURL(ctx)
-		if err != nil {
-			log.Info("Failed to detect console URL, fleet-navigation link may be empty", "error", err)
-		} else if err := os.Setenv("ACM_HUB_CONSOLE_URL", consoleURL); err != nil {
-			return ctrl.Result{}, pkgerrors.Wrapf(err, "failed to set ACM_HUB_CONSOLE_URL")
-		}
-
-		// If OCP 4.10+ then set then enable the MCE console. Else ensure it is disabled
-		currentClusterVersion, err := r.getClusterVersion(ctx)
-		if err != nil {
-			return ctrl.Result{}, pkgerrors.Wrapf(err, "failed to detect clusterversion")
-		}
-
-		// Set OCP version as env var, so that charts can render this value
-		os.Setenv("ACM_HUB_OCP_VERSION", currentClusterVersion)
-
-		currentVersion, err := semver.NewVersion(currentClusterVersion)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to convert currentClusterVersion %s to semver compatible value for comparison", currentClusterVersion))
-			return ctrl.Result{}, err
-		}
-		// -0 allows for prerelease builds to pass the validation.
-		// If -0 is removed, developer/rc builds will not pass this check
-		constraint, err := semver.NewConstraint(">= 4.10.0-0")
-		if err != nil {
-			log.Error(err, "Failed to set constraint of minimum supported version for plugins")
-			return ctrl.Result{}, err
-		}
+	return updateNecessary
+}
 
-		if constraint.Check(currentVersion) {
-			// If ConsoleMCE config already exists, then don't overwrite it
-			if !m.ComponentPresent(backplanev1.ConsoleMCE) {
-				log.Info("Dynamic plugins are supported. ConsoleMCE Config is not detected. Enabling ConsoleMCE")
-				m.Enable(backplanev1.ConsoleMCE)
-				updateNecessary = true
-			}
-		} else {
-			if m.Enabled(backplanev1.ConsoleMCE) {
-				log.Info("Dynamic plugins are not supported. Disabling MCE console")
-				m.Disable(backplanev1.ConsoleMCE)
-				updateNecessary = true
-			}
-		}
+/*
+setConsoleMCEDefault enables the MCE console on OpenShift 4.10 and newer, which support dynamic
+plugins, unless it is already configured, and disables it on older versions. It reports whether
+the MultiClusterEngine was changed.
+*/
+func setConsoleMCEDefault(m *backplanev1.MultiClusterEngine, clusterVersion string) (bool, error) {
+	currentVersion, err := semver.NewVersion(clusterVersion)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Failed to convert currentClusterVersion %s to semver compatible value for comparison", clusterVersion))
+		return false, err
+	}
+	// -0 allows for prerelease builds to pass the validation.
+	// If -0 is removed, developer/rc builds will not pass this check
+	constraint, err := semver.NewConstraint(">= 4.10.0-0")
+	if err != nil {
+		log.Error(err, "Failed to set constraint of minimum supported version for plugins")
+		return false, err
 	}
 
-	// Apply defaults to server
-	if updateNecessary {
-		log.Info("Setting defaults")
-		err := r.Client.Update(ctx, m)
-		if err != nil {
-			log.Error(err, "Failed to update MultiClusterEngine")
-			return ctrl.Result{}, err
+	if constraint.Check(currentVersion) {
+		// If ConsoleMCE config already exists, then don't overwrite it
+		if !m.ComponentPresent(backplanev1.ConsoleMCE) {
+			log.Info("Dynamic plugins are supported. ConsoleMCE Config is not detected. Enabling ConsoleMCE")
+			m.Enable(backplanev1.ConsoleMCE)
+			return true, nil
 		}
-		log.Info("MultiClusterEngine successfully updated")
-		return ctrl.Result{Requeue: true}, nil
-	} else {
-		return ctrl.Result{}, nil
+	} else if m.Enabled(backplanev1.ConsoleMCE) {
+		log.Info("Dynamic plugins are not supported. Disabling MCE console")
+		m.Disable(backplanev1.ConsoleMCE)
+		return true, nil
 	}
+	return false, nil
 }
 
 /*
@@ -2917,10 +3013,21 @@ func (r *MultiClusterEngineReconciler) CheckDeprecatedFieldUsage(m *backplanev1.
 }
 
 func EnsureCRD(ctx context.Context, c client.Client, crd *unstructured.Unstructured) error {
+	// In plan mode the create or update is only dry-run and recorded in the plan
+	pc, planning := planFromContext(ctx)
+
 	existingCRD := &unstructured.Unstructured{}
 	existingCRD.SetGroupVersionKind(crd.GroupVersionKind())
 	if err := c.Get(ctx, types.NamespacedName{Name: crd.GetName()}, existingCRD); err != nil {
 		if apierrors.IsNotFound(err) {
+			setTemplateHash(crd)
+			if planning {
+				if err = c.Create(ctx, crd, client.DryRunAll); err != nil {
+					return fmt.Errorf("error creating CRD '%s': %w", crd.GetName(), err)
+				}
+				pc.recordCreate(crd)
+				return nil
+			}
 			log.Info("Creating CRD", "Name", crd.GetName())
 			if err = c.Create(ctx, crd); err != nil {
 				return fmt.Errorf("error creating CRD '%s': %w", crd.GetName(), err)
@@ -2935,6 +3042,9 @@ func EnsureCRD(ctx context.Context, c client.Client, crd *unstructured.Unstructu
 			return nil
 		}
 
+		// The hash covers the rendered CRD, before the caBundle of the existing CRD is preserved
+		setTemplateHash(crd)
+
 		// Preserve caBundle from existing CRD if it exists (injected by cert-manager in vanilla K8s)
 		existingCABundle, found, err := unstructured.NestedString(existingCRD.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
 		if err == nil && found && existingCABundle != "" {
@@ -2945,9 +3055,21 @@ func EnsureCRD(ctx context.Context, c client.Client, crd *unstructured.Unstructu
 			log.V(1).Info("Preserved caBundle in CRD update", "Name", crd.GetName())
 		}
 
+		if templateUnchanged(crd, existingCRD) && len(computeDrift(crd, existingCRD)) == 0 {
+			return nil
+		}
+
 		// Set resource version for update
 		crd.SetResourceVersion(existingCRD.GetResourceVersion())
 
+		if planning {
+			if err = c.Update(ctx, crd, client.DryRunAll); err != nil {
+				return fmt.Errorf("error updating CRD '%s': %w", crd.GetName(), err)
+			}
+			pc.recordUpdate(crd)
+			return nil
+		}
+
 		// log.Info("Updating CRD", "Name", crd.GetName())
 		if err = c.Update(ctx, crd); err != nil {
 			return fmt.Errorf("error updating CRD '%s': %w", crd.GetName(), err)
@@ -30,7 +30,9 @@ import (
 	"time"
 
 	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 
@@ -1243,7 +1245,8 @@ var _ = Describe("BackplaneConfig controller", func() {
 				Expect(k8sClient.Create(createCtx, backplaneConfig)).Should(Succeed())
 				_, err := reconciler.ensureNoClusterManager(createCtx, backplaneConfig)
 				Expect(err).To(BeNil())
-				_, err = reconciler.ensureNoClusterLifecycle(createCtx, backplaneConfig)
+				clusterLifecycle, _ := lookupComponent(backplanev1.ClusterLifecycle)
+				_, err = clusterLifecycle.Disable(createCtx, &reconciler, backplaneConfig)
 				Expect(err).To(BeNil())
 				_, err = reconciler.ensureNoManagedServiceAccount(createCtx, backplaneConfig)
 				Expect(err).To(BeNil())
@@ -1251,7 +1254,8 @@ var _ = Describe("BackplaneConfig controller", func() {
 				Expect(err).To(BeNil())
 				_, err = reconciler.ensureNoHyperShift(createCtx, backplaneConfig)
 				Expect(err).To(BeNil())
-				_, err = reconciler.ensureNoServerFoundation(createCtx, backplaneConfig)
+				serverFoundation, _ := lookupComponent(backplanev1.ServerFoundation)
+				_, err = serverFoundation.Disable(createCtx, &reconciler, backplaneConfig)
 				Expect(err).To(BeNil())
 
 				By("ensuring each deployment and config is created")
@@ -1830,6 +1834,7 @@ func Test_applyEnvConfig(t *testing.T) {
 		template      *unstructured.Unstructured
 		envConfig     []backplanev1.EnvConfig
 		want          error
+		wantEnv       []corev1.EnvVar
 	}{
 		{
 			name:          "should apply env config",
@@ -1861,6 +1866,60 @@ func Test_applyEnvConfig(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name:          "should replace existing variables and apply value sources",
+			containerName: "hive-operator",
+			template: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata": map[string]interface{}{
+						"name":      "hive-operator",
+						"namespace": "test-ns",
+					},
+					"spec": map[string]interface{}{
+						"template": map[string]interface{}{
+							"spec": map[string]interface{}{
+								"containers": []interface{}{
+									map[string]interface{}{
+										"name": "hive-operator",
+										"env": []interface{}{
+											map[string]interface{}{"name": "LOG_LEVEL", "value": "info"},
+											map[string]interface{}{"name": "HIVE_NS", "value": "hive"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			envConfig: []backplanev1.EnvConfig{
+				{Name: "LOG_LEVEL", Value: "debug"},
+				{Name: "PROXY_URL", ValueFrom: &backplanev1.EnvConfigSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+						Key:                  "url",
+					},
+				}},
+				{Name: "HIVE_NS", ValueFrom: &backplanev1.EnvConfigSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+				}},
+			},
+			want: nil,
+			wantEnv: []corev1.EnvVar{
+				{Name: "LOG_LEVEL", Value: "debug"},
+				{Name: "HIVE_NS", ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+				}},
+				{Name: "PROXY_URL", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+						Key:                  "url",
+					},
+				}},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1875,6 +1934,12 @@ func Test_applyEnvConfig(t *testing.T) {
 			}
 
 			for _, c := range deployment.Spec.Template.Spec.Containers {
+				if c.Name == tt.containerName && tt.wantEnv != nil {
+					if !reflect.DeepEqual(c.Env, tt.wantEnv) {
+						t.Errorf("env of container %v = %v, want %v", c.Name, c.Env, tt.wantEnv)
+					}
+					break
+				}
 				if c.Name == tt.containerName {
 					// Ensure envConfig is correctly applied to container Env
 					for _, envVar := range tt.envConfig {
@@ -1896,6 +1961,405 @@ func Test_applyEnvConfig(t *testing.T) {
 	}
 }
 
+func Test_applyComponentDeploymentOverrides_replicas(t *testing.T) {
+	replicas := int32(3)
+	mce := &backplanev1.MultiClusterEngine{
+		Spec: backplanev1.MultiClusterEngineSpec{
+			Overrides: &backplanev1.Overrides{Components: []backplanev1.ComponentConfig{{
+				Name:    backplanev1.AssistedService,
+				Enabled: true,
+				ConfigOverrides: backplanev1.ConfigOverride{
+					Deployments: []backplanev1.DeploymentConfig{{Name: "assisted-service", Replicas: &replicas}},
+				},
+			}}},
+		},
+	}
+	deployment := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "test-ns"},
+			"spec":       map[string]interface{}{"replicas": int64(2)},
+		}}
+	}
+	templates := []*unstructured.Unstructured{deployment("assisted-service"), deployment("assisted-image-service")}
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	r.StatusManager.Reset("")
+
+	if _, err := r.applyComponentDeploymentOverrides(context.TODO(), mce, templates,
+		backplanev1.AssistedService); err != nil {
+		t.Fatalf("applyComponentDeploymentOverrides() = %v, want nil", err)
+	}
+	for name, want := range map[int]int64{0: 3, 1: 2} {
+		if got, _, _ := unstructured.NestedInt64(templates[name].Object, "spec", "replicas"); got != want {
+			t.Errorf("replicas of %s = %d, want %d", templates[name].GetName(), got, want)
+		}
+	}
+
+	footprint := r.StatusManager.Footprint[backplanev1.AssistedService]
+	if footprint.Deployments != 2 || footprint.Replicas != 5 {
+		t.Errorf("footprint = %v, want 2 deployments running 5 replicas", footprint)
+	}
+}
+
+func Test_applyContainerConfig(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{
+		Spec: backplanev1.MultiClusterEngineSpec{
+			Overrides: &backplanev1.Overrides{Components: []backplanev1.ComponentConfig{{
+				Name:    backplanev1.Hive,
+				Enabled: true,
+				ConfigOverrides: backplanev1.ConfigOverride{
+					Deployments: []backplanev1.DeploymentConfig{{
+						Name: "hive-operator",
+						Containers: []backplanev1.ContainerConfig{
+							{
+								Name:            "hive-operator",
+								Args:            []string{"--v=4"},
+								Image:           "quay.io/test/hive:fix",
+								ImagePullPolicy: corev1.PullAlways,
+							},
+							{Name: "missing", Image: "quay.io/test/missing:fix"},
+						},
+					}},
+				},
+			}}},
+		},
+	}
+	template := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "hive-operator", "namespace": "test-ns"},
+		"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":            "hive-operator",
+					"image":           "quay.io/stolostron/hive:latest",
+					"imagePullPolicy": "IfNotPresent",
+					"args":            []interface{}{"--log-level=info"},
+				},
+			},
+		}}},
+	}}
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	r.StatusManager.Reset("")
+
+	if _, err := r.applyComponentDeploymentOverrides(context.TODO(), mce, []*unstructured.Unstructured{template},
+		backplanev1.Hive); err != nil {
+		t.Fatalf("applyComponentDeploymentOverrides() = %v, want nil", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+		t.Fatalf("failed to convert unstructured object to deployment: %v", err)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if container.Image != "quay.io/test/hive:fix" || container.ImagePullPolicy != corev1.PullAlways ||
+		!reflect.DeepEqual(container.Args, []string{"--log-level=info", "--v=4"}) {
+		t.Errorf("container = %v, want the image, pull policy and arguments overridden", container)
+	}
+
+	want := []backplanev1.ContainerOverride{{
+		Component:       backplanev1.Hive,
+		Deployment:      "hive-operator",
+		Container:       "hive-operator",
+		Image:           "quay.io/test/hive:fix",
+		ImagePullPolicy: corev1.PullAlways,
+		Args:            []string{"--v=4"},
+	}}
+	if got := r.StatusManager.ReportStatus(*mce).ContainerOverrides; !reflect.DeepEqual(got, want) {
+		t.Errorf("containerOverrides = %v, want %v", got, want)
+	}
+}
+
+func Test_applyComponentDeploymentOverrides_podMetadata(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{
+		Spec: backplanev1.MultiClusterEngineSpec{
+			PodLabels:      map[string]string{"cost-center": "hub", "app": "mesh"},
+			PodAnnotations: map[string]string{"sidecar.istio.io/inject": "true", "logging/ship": "false"},
+			Overrides: &backplanev1.Overrides{Components: []backplanev1.ComponentConfig{{
+				Name:           backplanev1.Hive,
+				Enabled:        true,
+				PodLabels:      map[string]string{"cost-center": "provisioning"},
+				PodAnnotations: map[string]string{"logging/ship": "true"},
+			}}},
+		},
+	}
+	deployment := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "operator", "namespace": "test-ns"},
+			"spec": map[string]interface{}{"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels":      map[string]interface{}{"app": "operator"},
+					"annotations": map[string]interface{}{"sidecar.istio.io/inject": "false"},
+				},
+				"spec": map[string]interface{}{"containers": []interface{}{}},
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name            string
+		component       string
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "component with pod metadata overrides",
+			component:       backplanev1.Hive,
+			wantLabels:      map[string]string{"app": "operator", "cost-center": "provisioning"},
+			wantAnnotations: map[string]string{"sidecar.istio.io/inject": "true", "logging/ship": "true"},
+		},
+		{
+			name:            "component without overrides",
+			component:       backplanev1.Discovery,
+			wantLabels:      map[string]string{"app": "operator", "cost-center": "hub"},
+			wantAnnotations: map[string]string{"sidecar.istio.io/inject": "true", "logging/ship": "false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := deployment()
+			if _, err := reconciler.applyComponentDeploymentOverrides(context.TODO(), mce,
+				[]*unstructured.Unstructured{template}, tt.component); err != nil {
+				t.Fatalf("applyComponentDeploymentOverrides() = %v, want nil", err)
+			}
+			labels, _, _ := unstructured.NestedStringMap(template.Object, "spec", "template", "metadata", "labels")
+			if !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("pod labels = %v, want %v", labels, tt.wantLabels)
+			}
+			annotations, _, _ := unstructured.NestedStringMap(template.Object, "spec", "template", "metadata",
+				"annotations")
+			if !reflect.DeepEqual(annotations, tt.wantAnnotations) {
+				t.Errorf("pod annotations = %v, want %v", annotations, tt.wantAnnotations)
+			}
+		})
+	}
+}
+
+func Test_applySchedulingConfig(t *testing.T) {
+	template := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "assisted-service",
+				"namespace": "test-ns",
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"nodeSelector": map[string]interface{}{"node-role.kubernetes.io/infra": ""},
+						"tolerations": []interface{}{
+							map[string]interface{}{"key": "node-role.kubernetes.io/infra", "operator": "Exists"},
+						},
+						"containers": []interface{}{
+							map[string]interface{}{"name": "assisted-service"},
+						},
+					},
+				},
+			},
+		},
+	}
+	componentConfig := backplanev1.ComponentConfig{
+		Name:         backplanev1.AssistedService,
+		NodeSelector: map[string]string{"storage": "true"},
+		Tolerations: []corev1.Toleration{
+			{Key: "storage", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		},
+		Affinity: &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey: "kubernetes.io/hostname",
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "assisted-service"},
+						},
+					},
+				}},
+			},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		}},
+	}
+
+	if err := reconciler.applySchedulingConfig(template, componentConfig); err != nil {
+		t.Fatalf("applySchedulingConfig() = %v, want nil", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+		t.Fatalf("failed to convert unstructured object to deployment: %v", err)
+	}
+	podSpec := deployment.Spec.Template.Spec
+	if !reflect.DeepEqual(podSpec.NodeSelector, componentConfig.NodeSelector) {
+		t.Errorf("nodeSelector = %v, want %v", podSpec.NodeSelector, componentConfig.NodeSelector)
+	}
+	if !reflect.DeepEqual(podSpec.Tolerations, componentConfig.Tolerations) {
+		t.Errorf("tolerations = %v, want %v", podSpec.Tolerations, componentConfig.Tolerations)
+	}
+	if !reflect.DeepEqual(podSpec.Affinity, componentConfig.Affinity) {
+		t.Errorf("affinity = %v, want %v", podSpec.Affinity, componentConfig.Affinity)
+	}
+	if !reflect.DeepEqual(podSpec.TopologySpreadConstraints, componentConfig.TopologySpreadConstraints) {
+		t.Errorf("topologySpreadConstraints = %v, want %v", podSpec.TopologySpreadConstraints,
+			componentConfig.TopologySpreadConstraints)
+	}
+
+	// A component without scheduling overrides keeps the scheduling of the chart.
+	if err := reconciler.applySchedulingConfig(template, backplanev1.ComponentConfig{}); err != nil {
+		t.Fatalf("applySchedulingConfig() = %v, want nil", err)
+	}
+	if got, _, _ := unstructured.NestedStringMap(template.Object, "spec", "template", "spec",
+		"nodeSelector"); !reflect.DeepEqual(got, componentConfig.NodeSelector) {
+		t.Errorf("nodeSelector = %v, want %v", got, componentConfig.NodeSelector)
+	}
+}
+
+func Test_applySchedulingConfig_mergesAffinity(t *testing.T) {
+	haTerm := map[string]interface{}{
+		"weight": int64(100),
+		"podAffinityTerm": map[string]interface{}{
+			"topologyKey":   "kubernetes.io/hostname",
+			"labelSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "hive-operator"}},
+		},
+	}
+	template := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "hive-operator", "namespace": "test-ns"},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"affinity": map[string]interface{}{
+							"podAntiAffinity": map[string]interface{}{
+								"preferredDuringSchedulingIgnoredDuringExecution": []interface{}{haTerm},
+							},
+						},
+						"containers": []interface{}{map[string]interface{}{"name": "hive-operator"}},
+					},
+				},
+			},
+		},
+	}
+	componentConfig := backplanev1.ComponentConfig{
+		Name: backplanev1.Hive,
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key: "node-role.kubernetes.io/infra", Operator: corev1.NodeSelectorOpExists,
+						}},
+					}},
+				},
+			},
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight: 50,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey: "topology.kubernetes.io/zone",
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "hive-operator"},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	if err := reconciler.applySchedulingConfig(template, componentConfig); err != nil {
+		t.Fatalf("applySchedulingConfig() = %v, want nil", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+		t.Fatalf("failed to convert unstructured object to deployment: %v", err)
+	}
+	affinity := deployment.Spec.Template.Spec.Affinity
+	if !reflect.DeepEqual(affinity.NodeAffinity, componentConfig.Affinity.NodeAffinity) {
+		t.Errorf("nodeAffinity = %v, want %v", affinity.NodeAffinity, componentConfig.Affinity.NodeAffinity)
+	}
+	terms := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 2 || terms[0].PodAffinityTerm.TopologyKey != "kubernetes.io/hostname" ||
+		terms[1].PodAffinityTerm.TopologyKey != "topology.kubernetes.io/zone" {
+		t.Errorf("expected the pod anti-affinity of the override to be added to that of the chart, got %v", terms)
+	}
+}
+
+func Test_applyResourcesConfig(t *testing.T) {
+	template := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "hive-operator",
+				"namespace": "test-ns",
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name": "hive-operator",
+								"resources": map[string]interface{}{
+									"requests": map[string]interface{}{"cpu": "50m", "memory": "256Mi"},
+									"limits":   map[string]interface{}{"memory": "2Gi"},
+								},
+							},
+							map[string]interface{}{
+								"name": "sidecar",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+
+	if err := reconciler.applyResourcesConfig(template, "hive-operator", resources); err != nil {
+		t.Fatalf("applyResourcesConfig() = %v, want nil", err)
+	}
+	if err := reconciler.applyResourcesConfig(template, "sidecar", corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}); err != nil {
+		t.Fatalf("applyResourcesConfig() = %v, want nil", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+		t.Fatalf("failed to convert unstructured object to deployment: %v", err)
+	}
+
+	want := map[string]corev1.ResourceRequirements{
+		"hive-operator": {
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+		"sidecar": {
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		},
+	}
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		if !equality.Semantic.DeepEqual(c.Resources, want[c.Name]) {
+			t.Errorf("resources of container %s = %v, want %v", c.Name, c.Resources, want[c.Name])
+		}
+	}
+}
+
 func Test_ensureInternalEngineComponent(t *testing.T) {
 	tests := []struct {
 		name string
@@ -2190,48 +2654,6 @@ func Test_finalizeBackplaneConfig(t *testing.T) {
 	}
 }
 
-func Test_logAndSetCondition(t *testing.T) {
-	tests := []struct {
-		name     string
-		err      error
-		mce      *backplanev1.MultiClusterEngine
-		message  string
-		template *unstructured.Unstructured
-	}{
-		{
-			name: "should log and set condition of MCE",
-			err:  fmt.Errorf("Test error"),
-			mce: &backplanev1.MultiClusterEngine{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "multiclusterengine",
-					Namespace: "test-ns",
-				},
-			},
-			message: "This is a test error",
-			template: &unstructured.Unstructured{
-				Object: map[string]interface{}{
-					"apiVersion": "apps/v1",
-					"kind":       "Deployment",
-					"metadata": map[string]interface{}{
-						"name":      "test-deployment",
-						"namespace": "test-ns",
-					},
-					"spec": map[string]interface{}{},
-				},
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-
-			if _, err := recon.logAndSetCondition(tt.err, tt.message, tt.template, tt.mce); err == nil {
-				t.Errorf("logAndSetCondition() = %v, expected an error", err)
-			}
-		})
-	}
-}
-
 func Test_ensureResourceVersionAlignment(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -2512,7 +2934,7 @@ func Test_ensureToggleableComponents_withExternallyManagedComponents(t *testing.
 							`"cluster-permission","server-foundation","cluster-proxy-addon",` +
 							`"cluster-api","cluster-api-provider-aws",` +
 							`"cluster-api-provider-azure-preview",` +
-							`"cluster-api-provider-metal3-preview",` +
+							`"cluster-api-provider-metal3","cluster-api-provider-metal3-preview",` +
 							`"cluster-api-provider-openshift-assisted","local-cluster"]`,
 					},
 				},
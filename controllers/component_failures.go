@@ -0,0 +1,140 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// componentBackoffBase is the delay before the first retry of a failing component.
+	componentBackoffBase = 5 * time.Second
+	// componentBackoffMax caps the delay between retries of a failing component.
+	componentBackoffMax = 5 * time.Minute
+
+	// Names used to track failures of resources that are not toggleable components.
+	alwaysDeployedComponent  = "always-deployed"
+	networkPoliciesComponent = "network-policies"
+)
+
+// resourceError is returned when a rendered resource cannot be applied.
+type resourceError struct {
+	Kind string
+	Name string
+	err  error
+}
+
+func (e *resourceError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resourceError) Unwrap() error {
+	return e.err
+}
+
+// renderError is returned when the chart of a component cannot be rendered.
+type renderError struct {
+	errs []error
+}
+
+func (e *renderError) Error() string {
+	return errors.Join(e.errs...).Error()
+}
+
+func (e *renderError) Unwrap() []error {
+	return e.errs
+}
+
+/*
+renderFailure returns the outcome of a chart that failed to render. The errors are returned so that they are
+recorded as a failure of the component and retried with backoff; values that do not match the schema of the
+chart point at the offending template override.
+*/
+func renderFailure(errs []error) (ctrl.Result, error) {
+	return ctrl.Result{}, &renderError{errs: errs}
+}
+
+// componentBackoff returns how long to wait before retrying a component that failed the given number of times.
+func componentBackoff(failures int32) time.Duration {
+	backoff := componentBackoffBase
+	for i := int32(1); i < failures; i++ {
+		backoff *= 2
+		if backoff >= componentBackoffMax {
+			return componentBackoffMax
+		}
+	}
+	return backoff
+}
+
+/*
+reconcileWithBackoff calls fn unless the named component failed recently and its retry time has
+not been reached, in which case it only requeues for the retry. An error from fn is recorded as a
+component failure with an exponentially growing retry delay, and a success clears it.
+*/
+func (r *MultiClusterEngineReconciler) reconcileWithBackoff(name string,
+	fn func() (ctrl.Result, error)) (ctrl.Result, error) {
+	if retry, ok := r.backoffRemaining(name); ok {
+		return ctrl.Result{RequeueAfter: retry}, nil
+	}
+
+	result, err := fn()
+	if err == nil {
+		r.StatusManager.ClearFailure(name)
+		return result, nil
+	}
+	r.recordFailure(name, err)
+	return result, err
+}
+
+/*
+backoffRemaining returns how long the named component waits before it is retried, if it failed recently
+and its retry time has not been reached.
+*/
+func (r *MultiClusterEngineReconciler) backoffRemaining(name string) (time.Duration, bool) {
+	now := time.Now()
+	previous, failing := r.StatusManager.GetFailure(name)
+	if !failing || !now.Before(previous.NextRetryTime.Time) {
+		return 0, false
+	}
+	log.Info("Skipping component in backoff", "component", name, "failures", previous.Failures,
+		"nextRetry", previous.NextRetryTime.Time)
+	return previous.NextRetryTime.Sub(now), true
+}
+
+// recordFailure records err as a failure of the named component, retried with an exponentially growing delay.
+func (r *MultiClusterEngineReconciler) recordFailure(name string, err error) {
+	now := time.Now()
+	previous, _ := r.StatusManager.GetFailure(name)
+	failure := backplanev1.ComponentFailure{
+		Name:            name,
+		Reason:          status.DeployFailedReason,
+		Message:         err.Error(),
+		Failures:        previous.Failures + 1,
+		LastFailureTime: metav1.NewTime(now),
+	}
+	var resErr *resourceError
+	var renderErr *renderError
+	if errors.As(err, &renderErr) {
+		failure.Reason = status.RenderFailedReason
+	} else if errors.As(err, &resErr) {
+		failure.Kind = resErr.Kind
+		failure.ResourceName = resErr.Name
+		failure.Reason = status.ApplyFailedReason
+	}
+	var valuesErr *renderer.ValuesError
+	if errors.As(err, &valuesErr) {
+		failure.Reason = status.InvalidOverridesReason
+	}
+	failure.NextRetryTime = metav1.NewTime(now.Add(componentBackoff(failure.Failures)))
+
+	log.Error(err, "Component failed to reconcile", "component", name, "failures", failure.Failures,
+		"nextRetry", failure.NextRetryTime.Time)
+	r.StatusManager.SetFailure(failure)
+}
@@ -0,0 +1,238 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/toggle"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func Test_componentBackoff(t *testing.T) {
+	tests := []struct {
+		failures int32
+		want     time.Duration
+	}{
+		{failures: 0, want: componentBackoffBase},
+		{failures: 1, want: componentBackoffBase},
+		{failures: 2, want: 2 * componentBackoffBase},
+		{failures: 3, want: 4 * componentBackoffBase},
+		{failures: 100, want: componentBackoffMax},
+	}
+
+	for _, tt := range tests {
+		if got := componentBackoff(tt.failures); got != tt.want {
+			t.Errorf("componentBackoff(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func Test_reconcileWithBackoff(t *testing.T) {
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	r.StatusManager.Reset("")
+
+	calls := 0
+	applyErr := &resourceError{Kind: "Deployment", Name: "test-deployment", err: errors.New("apply failed")}
+	failing := func() (ctrl.Result, error) {
+		calls++
+		return ctrl.Result{}, applyErr
+	}
+
+	if _, err := r.reconcileWithBackoff("test", failing); !errors.Is(err, applyErr) {
+		t.Fatalf("reconcileWithBackoff() error = %v, want %v", err, applyErr)
+	}
+	failure, ok := r.StatusManager.GetFailure("test")
+	if !ok {
+		t.Fatalf("expected a failure to be recorded")
+	}
+	if failure.Failures != 1 || failure.Kind != "Deployment" || failure.ResourceName != "test-deployment" ||
+		failure.Reason != status.ApplyFailedReason {
+		t.Errorf("unexpected failure recorded: %+v", failure)
+	}
+	if got := failure.NextRetryTime.Sub(failure.LastFailureTime.Time); got != componentBackoffBase {
+		t.Errorf("expected retry after %v, got %v", componentBackoffBase, got)
+	}
+
+	// The component is skipped while it is in backoff
+	result, err := r.reconcileWithBackoff("test", failing)
+	if err != nil || calls != 1 {
+		t.Fatalf("expected the component to be skipped in backoff, got err=%v calls=%d", err, calls)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > componentBackoffBase {
+		t.Errorf("expected a requeue before the retry time, got %v", result.RequeueAfter)
+	}
+
+	// Once the retry time is reached the failure count grows
+	failure.NextRetryTime.Time = time.Now().Add(-time.Second)
+	r.StatusManager.SetFailure(failure)
+	if _, err := r.reconcileWithBackoff("test", failing); err == nil || calls != 2 {
+		t.Fatalf("expected the component to be retried, got err=%v calls=%d", err, calls)
+	}
+	if failure, _ = r.StatusManager.GetFailure("test"); failure.Failures != 2 {
+		t.Errorf("expected 2 failures, got %d", failure.Failures)
+	}
+
+	// A success clears the failure
+	failure.NextRetryTime.Time = time.Now().Add(-time.Second)
+	r.StatusManager.SetFailure(failure)
+	if _, err := r.reconcileWithBackoff("test", func() (ctrl.Result, error) {
+		return ctrl.Result{}, nil
+	}); err != nil {
+		t.Fatalf("reconcileWithBackoff() returned error: %v", err)
+	}
+	if _, ok := r.StatusManager.GetFailure("test"); ok {
+		t.Errorf("expected the failure to be cleared")
+	}
+}
+
+func Test_ensureToggleableComponents_prerequisiteFailure(t *testing.T) {
+	saved := componentRegistry
+	defer func() { componentRegistry = saved }()
+
+	checks := 0
+	component := newFakeComponent("addon", nil)
+	component.prerequisites = []componentPrerequisite{
+		func(context.Context, *MultiClusterEngineReconciler, *backplanev1.MultiClusterEngine) (bool, error) {
+			checks++
+			return false, errors.New("cluster manager unreachable")
+		},
+	}
+	componentRegistry = []Component{component}
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: "test-ns",
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{{Name: "addon", Enabled: true}},
+			},
+		},
+	}
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	r.StatusManager.Reset("")
+
+	if _, err := r.ensureToggleableComponents(context.Background(), mce); err == nil {
+		t.Fatal("expected the prerequisite error to be returned")
+	}
+	failure, failing := r.StatusManager.GetFailure("addon")
+	if !failing || failure.Failures != 1 || !strings.Contains(failure.Message, "cluster manager unreachable") {
+		t.Fatalf("expected the prerequisite error to be recorded as a failure, got %+v", failure)
+	}
+
+	// The prerequisites are not checked again before the retry time
+	result, err := r.ensureToggleableComponents(context.Background(), mce)
+	if err != nil || result.RequeueAfter == 0 || checks != 1 || component.enabled != 0 {
+		t.Errorf("expected the component to wait for its retry, got result=%v err=%v checks=%d enabled=%d",
+			result, err, checks, component.enabled)
+	}
+}
+
+func Test_logApplyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		message  string
+		template *unstructured.Unstructured
+	}{
+		{
+			name:    "should log and return a resource error",
+			err:     errors.New("Test error"),
+			message: "This is a test error",
+			template: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata": map[string]interface{}{
+						"name":      "test-deployment",
+						"namespace": "test-ns",
+					},
+					"spec": map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	r := &MultiClusterEngineReconciler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.logApplyError(tt.err, tt.message, tt.template)
+			if err == nil {
+				t.Fatalf("logApplyError() = %v, expected an error", err)
+			}
+
+			var resErr *resourceError
+			if !errors.As(err, &resErr) {
+				t.Fatalf("logApplyError() = %v, expected a resourceError", err)
+			}
+			if resErr.Kind != "Deployment" || resErr.Name != "test-deployment" {
+				t.Errorf("logApplyError() returned Kind: %s Name: %s, expected Kind: Deployment Name: test-deployment",
+					resErr.Kind, resErr.Name)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("logApplyError() = %v, expected to wrap %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func Test_renderFailure(t *testing.T) {
+	useTemplateFS(t, os.DirFS(".."))
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: backplanev1.DefaultTargetNamespace},
+	}
+	_, errs := renderer.RenderChart(toggle.ConsoleMCEChartsDir, mce, map[string]string{"console_mce": "test"},
+		map[string]string{"console_mce_deployment_container_cpu_limt": "1"})
+	if len(errs) == 0 {
+		t.Fatalf("expected the unknown template override to fail the render")
+	}
+
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	r.StatusManager.Reset("")
+	if _, err := r.reconcileWithBackoff("console-mce", func() (ctrl.Result, error) {
+		return renderFailure(errs)
+	}); err == nil {
+		t.Fatalf("expected the invalid values to be returned as an error")
+	}
+	failure, ok := r.StatusManager.GetFailure("console-mce")
+	if !ok || failure.Reason != status.InvalidOverridesReason ||
+		!strings.Contains(failure.Message, "console_mce_deployment_container_cpu_limt") {
+		t.Errorf("expected a failure pointing at the template override, got %+v", failure)
+	}
+
+	if _, err := r.reconcileWithBackoff("hive", func() (ctrl.Result, error) {
+		return renderFailure([]error{errors.New("render failed")})
+	}); err == nil {
+		t.Fatalf("expected other render errors to be returned as an error")
+	}
+	failure, ok = r.StatusManager.GetFailure("hive")
+	if !ok || failure.Reason != status.RenderFailedReason || failure.Message != "render failed" {
+		t.Errorf("expected a render failure to be recorded, got %+v", failure)
+	}
+	mceStatus := r.StatusManager.ReportStatus(*mce)
+	if len(mceStatus.ComponentFailures) != 2 {
+		t.Errorf("expected both components to be reported as failing, got %+v", mceStatus.ComponentFailures)
+	}
+	message := ""
+	for _, condition := range mceStatus.Conditions {
+		if condition.Type == backplanev1.MultiClusterEngineComponentFailure {
+			message = condition.Message
+		}
+	}
+	if !strings.Contains(message, "console-mce, hive") {
+		t.Errorf("expected the ComponentFailure condition to list both components, got %q", message)
+	}
+}
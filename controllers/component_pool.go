@@ -0,0 +1,150 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// componentConcurrencyEnvVar limits how many toggleable components are reconciled at the same time.
+	componentConcurrencyEnvVar  = "COMPONENT_CONCURRENCY"
+	defaultComponentConcurrency = 4
+)
+
+// componentConcurrency returns the maximum number of components reconciled in parallel.
+func componentConcurrency() int {
+	if val, ok := os.LookupEnv(componentConcurrencyEnvVar); ok && val != "" {
+		workers, err := strconv.Atoi(val)
+		if err == nil && workers > 0 {
+			return workers
+		}
+		log.Info("Ignoring invalid component concurrency", "env", componentConcurrencyEnvVar, "value", val)
+	}
+	return defaultComponentConcurrency
+}
+
+/*
+componentLocks serializes the reconciles of each component, so that the MultiClusterEngine and
+InternalEngineComponent controllers do not apply the same component at the same time while different
+components are reconciled concurrently.
+*/
+type componentLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock locks the named component and returns the function that unlocks it.
+func (l *componentLocks) lock(name string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*sync.Mutex{}
+	}
+	if l.locks[name] == nil {
+		l.locks[name] = &sync.Mutex{}
+	}
+	componentLock := l.locks[name]
+	l.mu.Unlock()
+
+	componentLock.Lock()
+	return componentLock.Unlock
+}
+
+// componentResult is the outcome of reconciling a single component.
+type componentResult struct {
+	index  int
+	result ctrl.Result
+	err    error
+}
+
+/*
+runComponents calls fn for every component using at most workers goroutines. A component
+is not started before the components named by waitsOn that are part of the same run have
+finished, so independent components are reconciled in parallel while dependency order is
+kept. When several components are ready they are started in the order given. It reports
+whether any component asked to be requeued and the errors returned per component.
+*/
+func runComponents(components []Component, workers int, waitsOn func(Component) []string,
+	fn func(Component) (ctrl.Result, error)) (bool, map[string]error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	index := map[string]int{}
+	for i, c := range components {
+		index[c.GetName()] = i
+	}
+	pending := make([]int, len(components))
+	unblocks := make([][]int, len(components))
+	for i, c := range components {
+		for _, name := range waitsOn(c) {
+			if j, ok := index[name]; ok && j != i {
+				pending[i]++
+				unblocks[j] = append(unblocks[j], i)
+			}
+		}
+	}
+
+	ready := []int{}
+	for i := range components {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	requeue := false
+	errs := map[string]error{}
+	results := make(chan componentResult)
+	started, running := 0, 0
+	for started < len(components) || running > 0 {
+		for running < workers && len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			started++
+			running++
+			go func() {
+				res := componentResult{index: i}
+				defer func() {
+					if p := recover(); p != nil {
+						res.err = fmt.Errorf("panic reconciling component: %v", p)
+					}
+					results <- res
+				}()
+				res.result, res.err = fn(components[i])
+			}()
+		}
+
+		if running == 0 {
+			// Only reachable if the components wait on each other in a cycle
+			for i, c := range components {
+				if pending[i] > 0 {
+					errs[c.GetName()] = fmt.Errorf("component is waiting on a dependency cycle")
+				}
+			}
+			break
+		}
+
+		res := <-results
+		running--
+		if res.result != (ctrl.Result{}) {
+			requeue = true
+		}
+		if res.err != nil {
+			errs[components[res.index].GetName()] = res.err
+		}
+		for _, next := range unblocks[res.index] {
+			pending[next]--
+			if pending[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+		slices.Sort(ready)
+	}
+	return requeue, errs
+}
@@ -0,0 +1,151 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func Test_componentConcurrency(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "unset", value: "", want: defaultComponentConcurrency},
+		{name: "valid", value: "8", want: 8},
+		{name: "not a number", value: "many", want: defaultComponentConcurrency},
+		{name: "not positive", value: "0", want: defaultComponentConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(componentConcurrencyEnvVar, tt.value)
+			if got := componentConcurrency(); got != tt.want {
+				t.Errorf("componentConcurrency() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runComponents(t *testing.T) {
+	t.Run("limits concurrency", func(t *testing.T) {
+		components := []Component{}
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			components = append(components, newFakeComponent(name, nil))
+		}
+
+		var inFlight, maxInFlight atomic.Int32
+		_, errs := runComponents(components, 2, Component.GetDependencies, func(Component) (ctrl.Result, error) {
+			current := inFlight.Add(1)
+			for {
+				seen := maxInFlight.Load()
+				if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			inFlight.Add(-1)
+			return ctrl.Result{}, nil
+		})
+
+		if len(errs) != 0 {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+		if maxInFlight.Load() > 2 {
+			t.Errorf("expected at most 2 components in flight, got %d", maxInFlight.Load())
+		}
+	})
+
+	t.Run("waits on dependencies and collects results", func(t *testing.T) {
+		components := []Component{
+			newFakeComponent("addon", nil, "manager"),
+			newFakeComponent("manager", nil),
+			newFakeComponent("broken", nil),
+			newFakeComponent("slow", nil),
+		}
+
+		var mu sync.Mutex
+		finished := map[string]bool{}
+		requeue, errs := runComponents(components, 4, Component.GetDependencies,
+			func(c Component) (ctrl.Result, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				for _, dep := range c.GetDependencies() {
+					if !finished[dep] {
+						t.Errorf("%s started before its dependency %s finished", c.GetName(), dep)
+					}
+				}
+				finished[c.GetName()] = true
+
+				switch c.GetName() {
+				case "broken":
+					return ctrl.Result{}, errors.New("apply failed")
+				case "slow":
+					return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+				}
+				return ctrl.Result{}, nil
+			})
+
+		if !requeue {
+			t.Errorf("expected a requeue to be requested")
+		}
+		if len(errs) != 1 || errs["broken"] == nil {
+			t.Errorf("expected a single error for the broken component, got %v", errs)
+		}
+		if len(finished) != len(components) {
+			t.Errorf("expected every component to run, got %v", finished)
+		}
+	})
+
+	t.Run("recovers from panics", func(t *testing.T) {
+		_, errs := runComponents([]Component{newFakeComponent("panics", nil)}, 1, Component.GetDependencies,
+			func(Component) (ctrl.Result, error) {
+				panic("boom")
+			})
+		if errs["panics"] == nil {
+			t.Errorf("expected the panic to be reported as an error")
+		}
+	})
+}
+
+func Test_componentLocks(t *testing.T) {
+	locks := &componentLocks{}
+	unlockA := locks.lock("a")
+
+	// Other components are not blocked by a locked component
+	done := make(chan struct{})
+	go func() {
+		locks.lock("b")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected component b to be locked while component a is")
+	}
+
+	var locked atomic.Bool
+	go func() {
+		defer locks.lock("a")()
+		locked.Store(true)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if locked.Load() {
+		t.Fatal("expected component a to be locked once at a time")
+	}
+	unlockA()
+	deadline := time.Now().Add(time.Second)
+	for !locked.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !locked.Load() {
+		t.Error("expected component a to be locked once unlocked")
+	}
+}
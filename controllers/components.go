@@ -0,0 +1,464 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/foundation"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/toggle"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+/*
+Component is a toggleable piece of the multicluster engine. Every component in the
+registry is reconciled by ensureToggleableComponents: components that are not supported
+on the current platform or that are externally managed are skipped, enabled components
+whose dependencies are installed and ready and whose prerequisites are met are passed to Enable,
+and every other component is passed to Disable. Components are disabled in reverse
+dependency order before the remaining components are enabled in dependency order;
+components that do not depend on each other may be enabled or disabled concurrently.
+*/
+type Component interface {
+	// GetName returns the component name as used in spec.overrides.components.
+	GetName() string
+	// GetChartDir returns the chart rendered for the component, or an empty string if the
+	// component is not chart based.
+	GetChartDir() string
+	// GetCRDDirs returns the CRD directories shipped with the component.
+	GetCRDDirs() []string
+	// GetStatusReporters returns the status reporters tracked while the component is enabled.
+	GetStatusReporters(mce *backplanev1.MultiClusterEngine) []status.StatusReporter
+	// GetDependencies returns the names of the components that must be installed before this one.
+	GetDependencies() []string
+	// GetExternalManagementNames returns the component names that, when listed in the
+	// externally-managed annotation, stop the operator from reconciling this component.
+	GetExternalManagementNames() []string
+	// IsSupported reports whether the component is reconciled at all on the current platform.
+	IsSupported() bool
+	// CanInstall reports whether the cluster meets the prerequisites for installing the component.
+	CanInstall(ctx context.Context, r *MultiClusterEngineReconciler, mce *backplanev1.MultiClusterEngine) (bool, error)
+	// IsReady reports whether the component serves the components that depend on it.
+	IsReady(ctx context.Context, r *MultiClusterEngineReconciler, mce *backplanev1.MultiClusterEngine) (bool, error)
+	// Enable installs or updates the component.
+	Enable(ctx context.Context, r *MultiClusterEngineReconciler, mce *backplanev1.MultiClusterEngine) (
+		ctrl.Result, error)
+	// Disable removes the component.
+	Disable(ctx context.Context, r *MultiClusterEngineReconciler, mce *backplanev1.MultiClusterEngine) (
+		ctrl.Result, error)
+}
+
+// componentHook installs or removes a component. Reconciler methods can be used directly as
+// hooks through method expressions, e.g. (*MultiClusterEngineReconciler).ensureHive.
+type componentHook func(r *MultiClusterEngineReconciler, ctx context.Context,
+	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error)
+
+// componentPrerequisite reports whether a requirement for installing a component is met.
+type componentPrerequisite func(ctx context.Context, r *MultiClusterEngineReconciler,
+	mce *backplanev1.MultiClusterEngine) (bool, error)
+
+/*
+chartComponent is the Component implementation used for the built-in components. Without
+hooks, the component is installed and removed by rendering its chart and applying or
+deleting every template (see ensureComponent and ensureNoComponent).
+*/
+type chartComponent struct {
+	name string
+	// chartDir is the chart rendered for the component. When k8sChartDir is set it is used
+	// instead on non-OpenShift clusters.
+	chartDir    string
+	k8sChartDir string
+	// crdDirs overrides the CRD directories reported by utils.ComponentCRDDirectories.
+	crdDirs []string
+	// skipCRDsWhenDisabled keeps the component CRDs from being applied while it is disabled.
+	skipCRDsWhenDisabled bool
+	// namespace overrides the namespace the chart is rendered into. Defaults to the
+	// MultiClusterEngine target namespace.
+	namespace func(mce *backplanev1.MultiClusterEngine) string
+	// deployments are tracked in the status while the component is enabled. ocpDeployments
+	// are only tracked on OpenShift.
+	deployments    []string
+	ocpDeployments []string
+	// ocpOnly components are left untouched on non-OpenShift clusters.
+	ocpOnly       bool
+	prerequisites []componentPrerequisite
+	// ready, when set, reports whether the component serves its dependents once installed.
+	ready componentPrerequisite
+	// externalManagement overrides the names checked against the externally-managed
+	// annotation. Defaults to the component name.
+	externalManagement []string
+	// ignoreExternalManagement components are reconciled even when listed as externally managed.
+	ignoreExternalManagement bool
+	enable                   componentHook
+	disable                  componentHook
+}
+
+func (c *chartComponent) GetName() string {
+	return c.name
+}
+
+func (c *chartComponent) GetChartDir() string {
+	if c.k8sChartDir != "" && !utils.DeployOnOCP() {
+		return c.k8sChartDir
+	}
+	return c.chartDir
+}
+
+func (c *chartComponent) GetCRDDirs() []string {
+	if c.crdDirs != nil {
+		return c.crdDirs
+	}
+	return utils.ComponentCRDDirectories(c.name)
+}
+
+func (c *chartComponent) GetStatusReporters(mce *backplanev1.MultiClusterEngine) []status.StatusReporter {
+	reporters := []status.StatusReporter{}
+	for _, nn := range c.deploymentNames(mce) {
+		reporters = append(reporters, toggle.EnabledStatus(nn))
+	}
+	return reporters
+}
+
+func (c *chartComponent) GetDependencies() []string {
+	return backplanev1.ComponentDependencies[c.name]
+}
+
+func (c *chartComponent) GetExternalManagementNames() []string {
+	if c.ignoreExternalManagement {
+		return nil
+	}
+	if c.externalManagement != nil {
+		return c.externalManagement
+	}
+	return []string{c.name}
+}
+
+func (c *chartComponent) IsSupported() bool {
+	return !c.ocpOnly || utils.DeployOnOCP()
+}
+
+func (c *chartComponent) CanInstall(ctx context.Context, r *MultiClusterEngineReconciler,
+	mce *backplanev1.MultiClusterEngine) (bool, error) {
+	for _, prerequisite := range c.prerequisites {
+		ok, err := prerequisite(ctx, r, mce)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (c *chartComponent) IsReady(ctx context.Context, r *MultiClusterEngineReconciler,
+	mce *backplanev1.MultiClusterEngine) (bool, error) {
+	if c.ready == nil {
+		return true, nil
+	}
+	return c.ready(ctx, r, mce)
+}
+
+func (c *chartComponent) Enable(ctx context.Context, r *MultiClusterEngineReconciler,
+	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+	if c.enable != nil {
+		return c.enable(r, ctx, mce)
+	}
+	return r.ensureComponent(ctx, mce, c)
+}
+
+func (c *chartComponent) Disable(ctx context.Context, r *MultiClusterEngineReconciler,
+	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+	if c.disable != nil {
+		return c.disable(r, ctx, mce)
+	}
+	return r.ensureNoComponent(ctx, mce, c)
+}
+
+// appliesChartOnly reports whether enabling the component only applies its chart.
+func (c *chartComponent) appliesChartOnly() bool {
+	return c.enable == nil
+}
+
+// targetNamespace returns the namespace the component chart is rendered into.
+func (c *chartComponent) targetNamespace(mce *backplanev1.MultiClusterEngine) string {
+	if c.namespace != nil {
+		return c.namespace(mce)
+	}
+	return mce.Spec.TargetNamespace
+}
+
+// deploymentNames returns the deployments tracked for the component on the current platform.
+func (c *chartComponent) deploymentNames(mce *backplanev1.MultiClusterEngine) []types.NamespacedName {
+	names := []types.NamespacedName{}
+	deployments := c.deployments
+	if utils.DeployOnOCP() {
+		deployments = append(append([]string{}, c.ocpDeployments...), deployments...)
+	}
+	for _, d := range deployments {
+		names = append(names, types.NamespacedName{Name: d, Namespace: c.targetNamespace(mce)})
+	}
+	return names
+}
+
+/*
+addonAPIServed requires the ClusterManagementAddOn CRD the cluster manager installs to be present on the
+cluster, so that the add-on components that depend on the cluster manager can be installed.
+*/
+func addonAPIServed(ctx context.Context, r *MultiClusterEngineReconciler,
+	_ *backplanev1.MultiClusterEngine) (bool, error) {
+	return foundation.CanInstallAddons(ctx, r.Client), nil
+}
+
+// ocpConsoleEnabled requires the OpenShift console capability to be enabled.
+func ocpConsoleEnabled(ctx context.Context, r *MultiClusterEngineReconciler,
+	_ *backplanev1.MultiClusterEngine) (bool, error) {
+	return r.CheckConsole(ctx)
+}
+
+// infrastructureNamespace returns the namespace for infrastructure operator resources.
+func infrastructureNamespace(mce *backplanev1.MultiClusterEngine) string {
+	if mce.Spec.Overrides != nil && mce.Spec.Overrides.InfrastructureCustomNamespace != "" {
+		return mce.Spec.Overrides.InfrastructureCustomNamespace
+	}
+	return mce.Spec.TargetNamespace
+}
+
+/*
+componentRegistry holds the components reconciled by ensureToggleableComponents, in
+reconcile order. Additional components can be added with RegisterComponent.
+*/
+var componentRegistry []Component
+
+func init() {
+	componentRegistry = builtinComponents()
+}
+
+// builtinComponents returns the components shipped with the operator, in reconcile order.
+func builtinComponents() []Component {
+	return []Component{
+		&chartComponent{
+			name:     backplanev1.ManagedServiceAccount,
+			chartDir: toggle.ManagedServiceAccountChartDir,
+			enable:   (*MultiClusterEngineReconciler).ensureManagedServiceAccount,
+			disable:  (*MultiClusterEngineReconciler).ensureNoManagedServiceAccount,
+		},
+		&chartComponent{
+			name:     backplanev1.FleetNavigation,
+			chartDir: toggle.FleetNavigationChartDir,
+			enable:   (*MultiClusterEngineReconciler).ensureFleetNavigation,
+			disable:  (*MultiClusterEngineReconciler).ensureNoFleetNavigation,
+		},
+		&chartComponent{
+			name:        backplanev1.ImageBasedInstallOperator,
+			chartDir:    toggle.ImageBasedInstallOperatorChartDir,
+			deployments: []string{"image-based-install-operator"},
+		},
+		&chartComponent{
+			name:        backplanev1.HyperShift,
+			chartDir:    toggle.HyperShiftChartDir,
+			deployments: []string{"hypershift-addon-manager"},
+			enable:      (*MultiClusterEngineReconciler).ensureHyperShift,
+			disable:     (*MultiClusterEngineReconciler).ensureNoHyperShift,
+		},
+		&chartComponent{
+			name:                     backplanev1.HypershiftLocalHosting,
+			ignoreExternalManagement: true,
+			enable:                   (*MultiClusterEngineReconciler).ensureHypershiftLocalHosting,
+			disable:                  (*MultiClusterEngineReconciler).ensureNoHypershiftLocalHosting,
+		},
+		&chartComponent{
+			name:          backplanev1.ConsoleMCE,
+			chartDir:      toggle.ConsoleMCEChartsDir,
+			deployments:   []string{"console-mce-console"},
+			ocpOnly:       true,
+			prerequisites: []componentPrerequisite{ocpConsoleEnabled},
+			enable:        (*MultiClusterEngineReconciler).ensureConsoleMCE,
+			disable: func(r *MultiClusterEngineReconciler, ctx context.Context,
+				mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+				ocpConsole, err := r.CheckConsole(ctx)
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+				return r.ensureNoConsoleMCE(ctx, mce, ocpConsole)
+			},
+		},
+		&chartComponent{
+			name:        backplanev1.Discovery,
+			chartDir:    toggle.DiscoveryChartDir,
+			deployments: []string{"discovery-operator"},
+		},
+		&chartComponent{
+			name:        backplanev1.Hive,
+			chartDir:    toggle.HiveChartDir,
+			deployments: []string{"hive-operator"},
+			enable:      (*MultiClusterEngineReconciler).ensureHive,
+			disable:     (*MultiClusterEngineReconciler).ensureNoHive,
+		},
+		&chartComponent{
+			name:        backplanev1.AssistedService,
+			chartDir:    toggle.AssistedServiceChartDir,
+			namespace:   infrastructureNamespace,
+			deployments: []string{"infrastructure-operator"},
+		},
+		&chartComponent{
+			name:     backplanev1.ClusterLifecycle,
+			chartDir: toggle.ClusterLifecycleChartDir,
+			ocpDeployments: []string{"cluster-curator-controller", "clusterclaims-controller",
+				"provider-credential-controller", "cluster-image-set-controller"},
+			deployments: []string{"clusterlifecycle-state-metrics-v2"},
+		},
+		&chartComponent{
+			name:        backplanev1.ClusterManager,
+			chartDir:    toggle.ClusterManagerChartDir,
+			deployments: []string{"cluster-manager"},
+			ready:       addonAPIServed,
+			enable:      (*MultiClusterEngineReconciler).ensureClusterManager,
+			disable:     (*MultiClusterEngineReconciler).ensureNoClusterManager,
+		},
+		&chartComponent{
+			name:        backplanev1.ClusterPermission,
+			chartDir:    toggle.ClusterPermissionChartDir,
+			deployments: []string{"cluster-permission"},
+		},
+		&chartComponent{
+			name:           backplanev1.ServerFoundation,
+			chartDir:       toggle.ServerFoundationChartDir,
+			ocpDeployments: []string{"ocm-proxyserver"},
+			deployments:    []string{"ocm-controller", "ocm-webhook"},
+		},
+		&chartComponent{
+			name:        backplanev1.ClusterProxyAddon,
+			chartDir:    toggle.ClusterProxyAddonDir,
+			deployments: []string{"cluster-proxy-addon-manager", "cluster-proxy-addon-user", "cluster-proxy"},
+			enable:      (*MultiClusterEngineReconciler).ensureClusterProxyAddon,
+			disable:     (*MultiClusterEngineReconciler).ensureNoClusterProxyAddon,
+		},
+		&chartComponent{
+			name:                 backplanev1.ClusterAPI,
+			chartDir:             toggle.ClusterAPIChartDir,
+			k8sChartDir:          toggle.ClusterAPIK8SChartDir,
+			skipCRDsWhenDisabled: true,
+			deployments:          []string{"capi-controller-manager"},
+		},
+		&chartComponent{
+			name:                 backplanev1.ClusterAPIProviderAWS,
+			chartDir:             toggle.ClusterAPIProviderAWSChartDir,
+			skipCRDsWhenDisabled: true,
+			deployments:          []string{"capa-controller-manager"},
+		},
+		&chartComponent{
+			name:                 backplanev1.ClusterAPIProviderAzurePreview,
+			chartDir:             toggle.ClusterAPIProviderAzureChartDir,
+			k8sChartDir:          toggle.ClusterAPIProviderAzureK8SChartDir,
+			skipCRDsWhenDisabled: true,
+			deployments:          []string{"azureserviceoperator-controller-manager", "capz-controller-manager"},
+		},
+		&chartComponent{
+			name:                 backplanev1.ClusterAPIProviderMetal,
+			chartDir:             toggle.ClusterAPIProviderMetalChartDir,
+			k8sChartDir:          toggle.ClusterAPIProviderMetalK8SChartDir,
+			skipCRDsWhenDisabled: true,
+			deployments:          []string{"mce-capm3-controller-manager"},
+		},
+		&chartComponent{
+			name:                 backplanev1.ClusterAPIProviderOA,
+			chartDir:             toggle.ClusterAPIProviderOAChartDir,
+			k8sChartDir:          toggle.ClusterAPIProviderOAK8SChartDir,
+			skipCRDsWhenDisabled: true,
+			deployments:          []string{"capoa-bootstrap-controller-manager", "capoa-controlplane-controller-manager"},
+		},
+		&chartComponent{
+			// Maestro configures the cluster-manager gRPC server, so it is left alone while the
+			// cluster-manager is externally managed.
+			name:               backplanev1.MaestroPreview,
+			chartDir:           toggle.MaestroChartDir,
+			namespace:          func(*backplanev1.MultiClusterEngine) string { return "maestro" },
+			deployments:        []string{"maestro"},
+			ocpOnly:            true,
+			externalManagement: []string{backplanev1.MaestroPreview, backplanev1.ClusterManager},
+			enable:             (*MultiClusterEngineReconciler).ensureMaestro,
+			disable:            (*MultiClusterEngineReconciler).ensureNoMaestro,
+		},
+		&chartComponent{
+			name:                     backplanev1.LocalCluster,
+			ignoreExternalManagement: true,
+			enable:                   (*MultiClusterEngineReconciler).ensureLocalCluster,
+			disable:                  (*MultiClusterEngineReconciler).ensureNoLocalCluster,
+		},
+	}
+}
+
+/*
+RegisterComponent adds a component to the registry. Registered components are reconciled
+after the built-in components, in registration order. It is meant to be called during
+operator setup, before the manager is started.
+*/
+func RegisterComponent(c Component) {
+	componentRegistry = append(componentRegistry, c)
+}
+
+// registeredComponents returns the components reconciled by the operator, in reconcile order.
+func registeredComponents() []Component {
+	return componentRegistry
+}
+
+/*
+componentInstallOrder sorts the components so that every component comes after the
+registered components it depends on. Components without ordering constraints keep their
+relative registry order. An error is returned if the dependencies contain a cycle.
+*/
+func componentInstallOrder(components []Component) ([]Component, error) {
+	registered := map[string]bool{}
+	for _, c := range components {
+		registered[c.GetName()] = true
+	}
+
+	ordered := make([]Component, 0, len(components))
+	placed := map[string]bool{}
+	for len(ordered) < len(components) {
+		progress := false
+		for _, c := range components {
+			if placed[c.GetName()] || !dependenciesPlaced(c, registered, placed) {
+				continue
+			}
+			ordered = append(ordered, c)
+			placed[c.GetName()] = true
+			progress = true
+		}
+
+		if !progress {
+			cycle := []string{}
+			for _, c := range components {
+				if !placed[c.GetName()] {
+					cycle = append(cycle, c.GetName())
+				}
+			}
+			return nil, fmt.Errorf("component dependency cycle detected between %s", strings.Join(cycle, ", "))
+		}
+	}
+	return ordered, nil
+}
+
+// dependenciesPlaced reports whether every registered dependency of the component has been placed.
+func dependenciesPlaced(c Component, registered, placed map[string]bool) bool {
+	for _, dep := range c.GetDependencies() {
+		if registered[dep] && !placed[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupComponent returns the registered component with the given name.
+func lookupComponent(name string) (Component, bool) {
+	for _, c := range componentRegistry {
+		if c.GetName() == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,277 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/toggle"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeComponent is a minimal Component used to exercise the registry.
+type fakeComponent struct {
+	chartComponent
+	dependencies []string
+	enabled      int
+	disabled     int
+	// calls, when set, records the order in which components are enabled and disabled.
+	calls *[]string
+}
+
+func (f *fakeComponent) GetDependencies() []string {
+	return f.dependencies
+}
+
+func (f *fakeComponent) Enable(context.Context, *MultiClusterEngineReconciler, *backplanev1.MultiClusterEngine) (
+	ctrl.Result, error) {
+	f.enabled++
+	if f.calls != nil {
+		*f.calls = append(*f.calls, "enable "+f.name)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (f *fakeComponent) Disable(context.Context, *MultiClusterEngineReconciler, *backplanev1.MultiClusterEngine) (
+	ctrl.Result, error) {
+	f.disabled++
+	if f.calls != nil {
+		*f.calls = append(*f.calls, "disable "+f.name)
+	}
+	return ctrl.Result{}, nil
+}
+
+func newFakeComponent(name string, calls *[]string, dependencies ...string) *fakeComponent {
+	return &fakeComponent{chartComponent: chartComponent{name: name}, dependencies: dependencies, calls: calls}
+}
+
+func componentNames(components []Component) []string {
+	names := []string{}
+	for _, c := range components {
+		names = append(names, c.GetName())
+	}
+	return names
+}
+
+func Test_builtinComponents(t *testing.T) {
+	seen := map[string]bool{}
+	for _, c := range builtinComponents() {
+		if seen[c.GetName()] {
+			t.Errorf("component %s is registered more than once", c.GetName())
+		}
+		seen[c.GetName()] = true
+
+		if !slices.Contains(backplanev1.AllComponents, c.GetName()) {
+			t.Errorf("component %s is not a valid component name", c.GetName())
+		}
+	}
+}
+
+func Test_fetchChartOrCRDPath(t *testing.T) {
+	r := &MultiClusterEngineReconciler{}
+
+	tests := []struct {
+		name      string
+		component string
+		ocp       bool
+		want      string
+	}{
+		{
+			name:      "chart component",
+			component: backplanev1.Discovery,
+			want:      toggle.DiscoveryChartDir,
+		},
+		{
+			name:      "openshift chart variant",
+			component: backplanev1.ClusterAPI,
+			ocp:       true,
+			want:      toggle.ClusterAPIChartDir,
+		},
+		{
+			name:      "kubernetes chart variant",
+			component: backplanev1.ClusterAPI,
+			want:      toggle.ClusterAPIK8SChartDir,
+		},
+		{
+			name:      "component without a chart",
+			component: backplanev1.LocalCluster,
+			want:      "",
+		},
+		{
+			name:      "unknown component",
+			component: "unknown",
+			want:      "/chart/toggle/unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployOnOCP := utils.DeployOnOCP()
+			defer utils.SetDeployOnOCP(deployOnOCP)
+			utils.SetDeployOnOCP(tt.ocp)
+
+			if got := r.fetchChartOrCRDPath(tt.component); got != tt.want {
+				t.Errorf("fetchChartOrCRDPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_RegisterComponent(t *testing.T) {
+	saved := componentRegistry
+	defer func() { componentRegistry = saved }()
+
+	componentRegistry = []Component{}
+	internal := &fakeComponent{chartComponent: chartComponent{name: "internal-component"}}
+	RegisterComponent(internal)
+
+	if c, ok := lookupComponent("internal-component"); !ok || c != Component(internal) {
+		t.Fatalf("lookupComponent() did not return the registered component")
+	}
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{{Name: "internal-component", Enabled: true}},
+			},
+		},
+	}
+
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	if _, err := r.ensureToggleableComponents(context.Background(), mce); err != nil {
+		t.Fatalf("ensureToggleableComponents() returned error: %v", err)
+	}
+	if internal.enabled != 1 || internal.disabled != 0 {
+		t.Errorf("expected component to be enabled once, got enabled=%d disabled=%d", internal.enabled,
+			internal.disabled)
+	}
+
+	mce.Spec.Overrides.Components[0].Enabled = false
+	if _, err := r.ensureToggleableComponents(context.Background(), mce); err != nil {
+		t.Fatalf("ensureToggleableComponents() returned error: %v", err)
+	}
+	if internal.disabled != 1 {
+		t.Errorf("expected component to be disabled once, got %d", internal.disabled)
+	}
+}
+
+func Test_unmetDependencies_clusterManagerReady(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = apixv1.AddToScheme(s)
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	msa, _ := lookupComponent(backplanev1.ManagedServiceAccount)
+	installed := map[string]bool{backplanev1.ClusterManager: true}
+
+	// The add-ons are blocked on the cluster manager until it serves the add-on API
+	r := &MultiClusterEngineReconciler{Client: fake.NewClientBuilder().WithScheme(s).Build()}
+	if unmet := r.unmetDependencies(context.Background(), mce, msa, installed); !slices.Equal(unmet,
+		[]string{backplanev1.ClusterManager}) {
+		t.Errorf("expected managed-serviceaccount to be blocked on cluster-manager, got %v", unmet)
+	}
+
+	crd := &apixv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "clustermanagementaddons.addon.open-cluster-management.io"},
+	}
+	r.Client = fake.NewClientBuilder().WithScheme(s).WithObjects(crd).Build()
+	if unmet := r.unmetDependencies(context.Background(), mce, msa, installed); len(unmet) > 0 {
+		t.Errorf("expected managed-serviceaccount to be installable, got unmet dependencies %v", unmet)
+	}
+}
+
+func Test_componentInstallOrder(t *testing.T) {
+	ordered, err := componentInstallOrder(builtinComponents())
+	if err != nil {
+		t.Fatalf("componentInstallOrder() returned error: %v", err)
+	}
+	names := componentNames(ordered)
+	for component, deps := range backplanev1.ComponentDependencies {
+		for _, dep := range deps {
+			if slices.Index(names, dep) > slices.Index(names, component) {
+				t.Errorf("expected %s to be installed before %s, got %v", dep, component, names)
+			}
+		}
+	}
+
+	calls := []string{}
+	_, err = componentInstallOrder([]Component{
+		newFakeComponent("a", &calls, "b"),
+		newFakeComponent("b", &calls, "a"),
+		newFakeComponent("c", &calls),
+	})
+	if err == nil {
+		t.Errorf("expected an error for a dependency cycle")
+	}
+}
+
+func Test_ensureToggleableComponents_dependencies(t *testing.T) {
+	saved := componentRegistry
+	defer func() { componentRegistry = saved }()
+
+	calls := []string{}
+	componentRegistry = []Component{
+		newFakeComponent("addon", &calls, "manager"),
+		newFakeComponent("manager", &calls),
+		newFakeComponent("hosting", &calls, "addon", "manager"),
+	}
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: "test-ns",
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{
+					{Name: "addon", Enabled: true},
+					{Name: "manager", Enabled: true},
+					{Name: "hosting", Enabled: true},
+				},
+			},
+		},
+	}
+
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	if _, err := r.ensureToggleableComponents(context.Background(), mce); err != nil {
+		t.Fatalf("ensureToggleableComponents() returned error: %v", err)
+	}
+	want := []string{"enable manager", "enable addon", "enable hosting"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("expected components to be installed in dependency order %v, got %v", want, calls)
+	}
+
+	calls = []string{}
+	r.StatusManager.Reset("")
+	mce.Spec.Overrides.Components = []backplanev1.ComponentConfig{
+		{Name: "addon", Enabled: true},
+		{Name: "manager", Enabled: false},
+		{Name: "hosting", Enabled: true},
+	}
+	if _, err := r.ensureToggleableComponents(context.Background(), mce); err != nil {
+		t.Fatalf("ensureToggleableComponents() returned error: %v", err)
+	}
+	want = []string{"disable hosting", "disable addon", "disable manager"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("expected components to be removed in reverse dependency order %v, got %v", want, calls)
+	}
+
+	mceStatus := r.StatusManager.ReportStatus(*mce)
+	addon := getComponent(mceStatus.Components, "addon")
+	if addon.Reason != status.DependencyBlockedReason || addon.Message != "Blocked on manager" {
+		t.Errorf("expected addon to be blocked on manager, got %s: %s", addon.Reason, addon.Message)
+	}
+	hosting := getComponent(mceStatus.Components, "hosting")
+	if hosting.Message != "Blocked on addon, manager" {
+		t.Errorf("expected hosting to be blocked on addon and manager, got %s", hosting.Message)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// driftEventReason is the reason of the event emitted when a managed resource drifted.
+	driftEventReason = "ResourceDrifted"
+)
+
+// driftedFieldsMetric reports the number of drifted fields per managed resource found in the last reconcile.
+var driftedFieldsMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "mce_resource_drifted_fields",
+	Help: "Number of fields of a managed resource that differ from its rendered template.",
+}, []string{"kind", "namespace", "name"})
+
+func init() {
+	metrics.Registry.MustRegister(driftedFieldsMetric)
+}
+
+// reportDriftMetrics replaces the drift metric with the drifted resources found in the last reconcile.
+func reportDriftMetrics(drift []backplanev1.ResourceDrift) {
+	driftedFieldsMetric.Reset()
+	for _, d := range drift {
+		driftedFieldsMetric.WithLabelValues(d.Kind, d.Namespace, d.Name).Set(float64(len(d.Fields)))
+	}
+}
+
+/*
+computeDrift returns the paths of the fields set in the rendered template whose value differs in the
+live object. Fields the template does not set, such as defaults added by the API server, are not
+compared. Of the metadata only the labels and annotations are compared, except the template hash.
+*/
+func computeDrift(template, live *unstructured.Unstructured) []string {
+	fields := []string{}
+	for key, desired := range template.Object {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			for _, metaKey := range []string{"labels", "annotations"} {
+				desiredMeta, ok := template.Object["metadata"].(map[string]interface{})[metaKey]
+				if !ok {
+					continue
+				}
+				// The template hash is bookkeeping of the operator rather than desired state
+				if annotations, ok := desiredMeta.(map[string]interface{}); ok && metaKey == "annotations" {
+					if _, ok := annotations[utils.AnnotationTemplateHash]; ok {
+						annotations = maps.Clone(annotations)
+						delete(annotations, utils.AnnotationTemplateHash)
+						desiredMeta = annotations
+					}
+				}
+				liveMeta, found, _ := unstructured.NestedFieldNoCopy(live.Object, "metadata", metaKey)
+				fields = append(fields, driftedFields("metadata."+metaKey, desiredMeta, liveMeta, found)...)
+			}
+			continue
+		}
+		liveValue, found := live.Object[key]
+		fields = append(fields, driftedFields(key, desired, liveValue, found)...)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// driftedFields compares a desired value with the live value found at the same path.
+func driftedFields(path string, desired, live interface{}, found bool) []string {
+	if !found {
+		if isEmptyValue(desired) {
+			return nil
+		}
+		return []string{path}
+	}
+
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+		fields := []string{}
+		for key, value := range d {
+			liveValue, found := l[key]
+			fields = append(fields, driftedFields(driftFieldPath(path, key), value, liveValue, found)...)
+		}
+		return fields
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return []string{path}
+		}
+		fields := []string{}
+		for i := range d {
+			fields = append(fields, driftedFields(fmt.Sprintf("%s[%d]", path, i), d[i], l[i], true)...)
+		}
+		return fields
+	default:
+		if desired == nil || equality.Semantic.DeepEqual(desired, live) || fmt.Sprint(desired) == fmt.Sprint(live) {
+			return nil
+		}
+		return []string{path}
+	}
+}
+
+// driftFieldPath appends a key to a field path, quoting keys that contain separators such as label keys.
+func driftFieldPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	return path + "." + key
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+/*
+reportDrift compares the rendered template with the live resource and records any drift in the
+status, as an event and as a metric. It returns whether the resource drifted.
+*/
+func (r *MultiClusterEngineReconciler) reportDrift(backplaneConfig *backplanev1.MultiClusterEngine,
+	template, live *unstructured.Unstructured) bool {
+	fields := computeDrift(template, live)
+	if len(fields) == 0 {
+		return false
+	}
+
+	overwrite := !utils.IsTemplateAnnotationTrue(template, utils.AnnotationEditable) &&
+		!utils.IsDriftReportOnly(backplaneConfig)
+	r.StatusManager.AddDrift(backplanev1.ResourceDrift{
+		Kind:        live.GetKind(),
+		Namespace:   live.GetNamespace(),
+		Name:        live.GetName(),
+		Fields:      fields,
+		Overwritten: overwrite,
+	})
+
+	log.Info("Managed resource drifted from its rendered template", "Kind", live.GetKind(), "Name", live.GetName(),
+		"Namespace", live.GetNamespace(), "Fields", fields, "Overwritten", overwrite)
+
+	if r.Recorder != nil {
+		action := "Report"
+		if overwrite {
+			action = "Overwrite"
+		}
+		r.Recorder.Eventf(backplaneConfig, nil, corev1.EventTypeWarning, driftEventReason, action,
+			"%s drifted from its rendered template: %s", planResourceID(live), strings.Join(fields, ", "))
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"github.com/stolostron/backplane-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_computeDrift(t *testing.T) {
+	template := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":   "test",
+			"labels": map[string]interface{}{"app.kubernetes.io/name": "test"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "test", "image": "quay.io/test:1"},
+					},
+					"nodeSelector": map[string]interface{}{},
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name   string
+		mutate func(live *unstructured.Unstructured)
+		want   []string
+	}{
+		{
+			name: "server defaults are not drift",
+			mutate: func(live *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(live.Object, "Always",
+					"spec", "template", "spec", "restartPolicy")
+				_ = unstructured.SetNestedField(live.Object, "1", "metadata", "resourceVersion")
+			},
+			want: []string{},
+		},
+		{
+			name: "changed fields are reported",
+			mutate: func(live *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(live.Object, int64(5), "spec", "replicas")
+				_ = unstructured.SetNestedSlice(live.Object, []interface{}{
+					map[string]interface{}{"name": "test", "image": "quay.io/test:2"},
+				}, "spec", "template", "spec", "containers")
+				live.SetLabels(map[string]string{"app.kubernetes.io/name": "other"})
+			},
+			want: []string{
+				`metadata.labels["app.kubernetes.io/name"]`,
+				"spec.replicas",
+				"spec.template.spec.containers[0].image",
+			},
+		},
+		{
+			name: "removed fields are reported",
+			mutate: func(live *unstructured.Unstructured) {
+				unstructured.RemoveNestedField(live.Object, "spec", "replicas")
+			},
+			want: []string{"spec.replicas"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := template.DeepCopy()
+			unstructured.RemoveNestedField(live.Object, "spec", "template", "spec", "nodeSelector")
+			tt.mutate(live)
+			if got := computeDrift(template, live); !slices.Equal(got, tt.want) {
+				t.Errorf("computeDrift() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_applyTemplate_driftReportOnly(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+	t.Setenv("OPERATOR_VERSION", version.Version)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "multiclusterengine",
+			Annotations: map[string]string{utils.AnnotationDriftReportOnly: "true"},
+		},
+		Spec: backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	live := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "drifted",
+			Namespace:   "test-ns",
+			Labels:      map[string]string{"backplaneconfig.name": mce.GetName()},
+			Annotations: map[string]string{utils.AnnotationReleaseVersion: version.Version},
+		},
+		Data: map[string]string{"key": "edited"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce, live).Build()
+	recorder := events.NewFakeRecorder(1)
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: s, StatusManager: &status.StatusTracker{Client: cl},
+		Recorder: recorder}
+	r.StatusManager.Reset("")
+
+	template := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "drifted",
+			"namespace": "test-ns",
+			"labels":    map[string]interface{}{"backplaneconfig.name": mce.GetName()},
+		},
+		"data": map[string]interface{}{"key": "rendered"},
+	}}
+	applyReleaseVersionAnnotation(template)
+	if _, err := r.applyTemplate(context.Background(), mce, template); err != nil {
+		t.Fatalf("applyTemplate() returned error: %v", err)
+	}
+
+	drift := r.StatusManager.ReportStatus(*mce).DriftedResources
+	if len(drift) != 1 || !slices.Equal(drift[0].Fields, []string{"data.key"}) || drift[0].Overwritten {
+		t.Errorf("expected data.key to be reported as drift without being overwritten, got %v", drift)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := cl.Get(context.Background(), types.NamespacedName{Name: "drifted", Namespace: "test-ns"}, cm); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if cm.Data["key"] != "edited" {
+		t.Errorf("expected the drifted ConfigMap to be left untouched in report-only mode, got %v", cm.Data)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, driftEventReason) || !strings.Contains(event, "data.key") {
+			t.Errorf("unexpected drift event: %s", event)
+		}
+	default:
+		t.Errorf("expected a drift event to be recorded")
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"github.com/stolostron/backplane-operator/pkg/version"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// internalEngineComponentSpec returns the effective configuration of the component in the MultiClusterEngine.
+func internalEngineComponentSpec(backplaneConfig *backplanev1.MultiClusterEngine,
+	component string) backplanev1.InternalEngineComponentSpec {
+	spec := backplanev1.InternalEngineComponentSpec{Enabled: backplaneConfig.Enabled(component)}
+	if backplaneConfig.Spec.Overrides == nil {
+		return spec
+	}
+	for _, c := range backplaneConfig.Spec.Overrides.Components {
+		if c.Name == component {
+			spec.ConfigOverrides = *c.ConfigOverrides.DeepCopy()
+		}
+	}
+	return spec
+}
+
+/*
+componentConfigHash returns the hash of the configuration the component is rendered with: the spec, labels
+and annotations of the MultiClusterEngine, the image and template overrides and the patches of the
+component, including those read from ConfigMaps.
+*/
+func (r *MultiClusterEngineReconciler) componentConfigHash(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, component string) (string, error) {
+	patches := []string{}
+	if backplaneConfig.Spec.Overrides != nil {
+		componentConfig, _ := r.getComponentConfig(backplaneConfig.Spec.Overrides.Components, component)
+		for i, patch := range componentConfig.ConfigOverrides.Patches {
+			if patch.ConfigMapRef == nil {
+				continue
+			}
+			raw, err := r.resolvePatch(ctx, backplaneConfig, patch)
+			if err != nil {
+				return "", fmt.Errorf("failed to read patch %d of %s: %w", i, component, err)
+			}
+			patches = append(patches, string(raw))
+		}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"spec":              backplaneConfig.Spec,
+		"labels":            backplaneConfig.GetLabels(),
+		"annotations":       backplaneConfig.GetAnnotations(),
+		"imageOverrides":    r.CacheSpec.ImageOverrides,
+		"templateOverrides": r.CacheSpec.TemplateOverrides,
+		"patches":           patches,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+/*
+handOffSupported reports whether the component can be reconciled by the InternalEngineComponent
+controller, which is the case when enabling it only applies its chart. Components with their own install
+hooks reconcile resources beyond their chart and report status beyond their deployments, so they are
+applied by the MultiClusterEngine controller on every reconcile.
+*/
+func handOffSupported(component Component) bool {
+	c, ok := component.(interface{ appliesChartOnly() bool })
+	return ok && c.appliesChartOnly()
+}
+
+/*
+handedOff reports whether the component is left to the InternalEngineComponent controller instead of
+being applied by the MultiClusterEngine controller. A component that supports it is handed off once its
+InternalEngineComponent is healthy and records that every resource was applied by this operator version
+with the current configuration. Failing components and components of a MultiClusterEngine that only
+reports drift are still applied on every reconcile. The InternalEngineComponent of a component handed off
+is returned.
+*/
+func (r *MultiClusterEngineReconciler) handedOff(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, component Component) (*backplanev1.InternalEngineComponent, bool) {
+	if r.Client == nil || !handOffSupported(component) || utils.IsDriftReportOnly(backplaneConfig) {
+		return nil, false
+	}
+	if _, failing := r.StatusManager.GetFailure(component.GetName()); failing {
+		return nil, false
+	}
+
+	iec := &backplanev1.InternalEngineComponent{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: component.GetName(),
+		Namespace: backplaneConfig.Spec.TargetNamespace}, iec); err != nil || iec.GetDeletionTimestamp() != nil {
+		return nil, false
+	}
+	configHash, err := r.componentConfigHash(ctx, backplaneConfig, component.GetName())
+	if err != nil {
+		log.Info("Failed to hash the configuration of the component", "component", component.GetName(),
+			"error", err.Error())
+		return nil, false
+	}
+	return iec, iec.Status.Health == backplanev1.ComponentHealthy &&
+		iec.Status.LastAppliedVersion == version.Version &&
+		iec.Status.ConfigHash != "" && iec.Status.ConfigHash == configHash
+}
+
+/*
+reportHandedOff tracks the status of a component handed off to the InternalEngineComponent controller.
+Its deployments are tracked like those of an applied component, the drift the InternalEngineComponent
+controller last found is reported from the InternalEngineComponent, and the footprint and container
+overrides last recorded for it are kept, as they are unchanged while its configuration is.
+*/
+func (r *MultiClusterEngineReconciler) reportHandedOff(backplaneConfig *backplanev1.MultiClusterEngine,
+	component Component, iec *backplanev1.InternalEngineComponent) {
+	for _, reporter := range component.GetStatusReporters(backplaneConfig) {
+		r.StatusManager.AddComponent(reporter)
+	}
+	for _, drift := range iec.Status.DriftedResources {
+		r.StatusManager.AddDrift(drift)
+	}
+	for _, footprint := range backplaneConfig.Status.Footprint {
+		if footprint.Name == component.GetName() {
+			r.StatusManager.AddComponentFootprint(footprint)
+		}
+	}
+	for _, override := range backplaneConfig.Status.ContainerOverrides {
+		if override.Component == component.GetName() {
+			r.StatusManager.AddContainerOverride(override)
+		}
+	}
+}
+
+/*
+adoptComponentFailure records the failure last recorded on the InternalEngineComponent of the component,
+so that a component the InternalEngineComponent controller failed to reconcile is reported on the
+MultiClusterEngine and keeps its backoff. A failure already tracked for the component is only replaced
+by a more recent one.
+*/
+func (r *MultiClusterEngineReconciler) adoptComponentFailure(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, component string) {
+	if r.Client == nil {
+		return
+	}
+	iec := &backplanev1.InternalEngineComponent{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: component,
+		Namespace: backplaneConfig.Spec.TargetNamespace}, iec); err != nil || iec.Status.Failure == nil {
+		return
+	}
+	previous, failing := r.StatusManager.GetFailure(component)
+	if failing && !previous.LastFailureTime.Before(&iec.Status.Failure.LastFailureTime) {
+		return
+	}
+	r.StatusManager.SetFailure(*iec.Status.Failure)
+}
+
+// componentDrift returns the drifted resources that are among the resources of a component.
+func componentDrift(drift []backplanev1.ResourceDrift,
+	resources []backplanev1.AppliedResource) []backplanev1.ResourceDrift {
+	var result []backplanev1.ResourceDrift
+	for _, d := range drift {
+		for _, resource := range resources {
+			if d.Kind == resource.Kind && d.Namespace == resource.Namespace && d.Name == resource.Name {
+				result = append(result, d)
+				break
+			}
+		}
+	}
+	return result
+}
+
+/*
+componentReconciler returns a reconciler that applies single components with a snapshot of the caches of
+r, so that components can be reconciled while the MultiClusterEngine is. The reconciler tracks status on
+its own, so that the outcome of a component is only recorded on its InternalEngineComponent.
+*/
+func (r *MultiClusterEngineReconciler) componentReconciler() *MultiClusterEngineReconciler {
+	r.cacheMu.RLock()
+	defer r.cacheMu.RUnlock()
+
+	cache := r.CacheSpec
+	cache.ImageOverrides = maps.Clone(r.CacheSpec.ImageOverrides)
+	cache.TemplateOverrides = maps.Clone(r.CacheSpec.TemplateOverrides)
+	tracker := &status.StatusTracker{Client: r.Client}
+	tracker.Reset("")
+	return &MultiClusterEngineReconciler{
+		Client:          r.Client,
+		UncachedClient:  r.UncachedClient,
+		CacheSpec:       cache,
+		Scheme:          r.Scheme,
+		Images:          r.Images,
+		StatusManager:   tracker,
+		Recorder:        r.Recorder,
+		Log:             log,
+		UpgradeableCond: r.UpgradeableCond,
+		OLMVersion:      r.OLMVersion,
+	}
+}
+
+/*
+componentStatusChanged matches updates that change the health, failure or drifted resources of an
+InternalEngineComponent, which are aggregated in the status of the MultiClusterEngine.
+*/
+var componentStatusChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldIEC, ok := e.ObjectOld.(*backplanev1.InternalEngineComponent)
+		if !ok {
+			return false
+		}
+		newIEC, ok := e.ObjectNew.(*backplanev1.InternalEngineComponent)
+		return ok && (oldIEC.Status.Health != newIEC.Status.Health ||
+			!equality.Semantic.DeepEqual(oldIEC.Status.Failure, newIEC.Status.Failure) ||
+			!equality.Semantic.DeepEqual(oldIEC.Status.DriftedResources, newIEC.Status.DriftedResources))
+	},
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+/*
+internalEngineComponentRequests maps an InternalEngineComponent to the MultiClusterEngine whose target
+namespace it is in, so that the status of the components handed off is aggregated when it changes.
+*/
+func (r *MultiClusterEngineReconciler) internalEngineComponentRequests(ctx context.Context,
+	o client.Object) []reconcile.Request {
+	mces := &backplanev1.MultiClusterEngineList{}
+	if err := r.Client.List(ctx, mces); err != nil {
+		log.Error(err, "Failed to list MultiClusterEngines", "InternalEngineComponent", o.GetName())
+		return nil
+	}
+	for _, mce := range mces.Items {
+		if mce.Spec.TargetNamespace == o.GetNamespace() {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: mce.GetName()}}}
+		}
+	}
+	return nil
+}
+
+/*
+updateInternalEngineComponent records the outcome of reconciling the component on its
+InternalEngineComponent: the spec is refreshed with the effective configuration and the images
+applied, and the status with the health, applied resources, failure, drift and conditions of the component.
+Components without an InternalEngineComponent, and reconciles that do not record an inventory,
+are ignored.
+*/
+func (r *MultiClusterEngineReconciler) updateInternalEngineComponent(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, component string, result ctrl.Result, reconcileErr error) error {
+	scope, ok := inventoryFromContext(ctx)
+	if _, planning := planFromContext(ctx); planning || !ok {
+		return nil
+	}
+
+	iec := &backplanev1.InternalEngineComponent{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: component,
+		Namespace: backplaneConfig.Spec.TargetNamespace}, iec)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get InternalEngineComponent: %s/%s: %v",
+			backplaneConfig.Spec.TargetNamespace, component, err)
+	}
+	if iec.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	complete := scope.inventory.isComplete(component)
+
+	spec := internalEngineComponentSpec(backplaneConfig, component)
+	spec.Images = iec.Spec.Images
+	if complete {
+		spec.Images = scope.inventory.appliedImages(component)
+	}
+	if !equality.Semantic.DeepEqual(iec.Spec, spec) {
+		iec.Spec = spec
+		if err := r.Client.Update(ctx, iec); err != nil {
+			return fmt.Errorf("failed to update InternalEngineComponent: %s/%s: %v",
+				iec.GetNamespace(), iec.GetName(), err)
+		}
+	}
+
+	newStatus := iec.Status.DeepCopy()
+	if complete {
+		newStatus.AppliedResources = scope.inventory.appliedResources(component)
+		newStatus.LastAppliedVersion = version.Version
+		// A configuration that cannot be hashed is not recorded, so that the component is not handed off
+		newStatus.ConfigHash, _ = r.componentConfigHash(ctx, backplaneConfig, component)
+		newStatus.DriftedResources = componentDrift(r.StatusManager.GetDrift(), newStatus.AppliedResources)
+	}
+	newStatus.Failure = nil
+	if failure, failing := r.StatusManager.GetFailure(component); failing {
+		newStatus.Failure = &failure
+	}
+	r.setInternalEngineComponentConditions(iec, newStatus, result, reconcileErr)
+
+	if equality.Semantic.DeepEqual(iec.Status, *newStatus) {
+		return nil
+	}
+	iec.Status = *newStatus
+	if err := r.Client.Status().Update(ctx, iec); err != nil {
+		return fmt.Errorf("failed to update InternalEngineComponent status: %s/%s: %v",
+			iec.GetNamespace(), iec.GetName(), err)
+	}
+	return nil
+}
+
+/*
+setInternalEngineComponentConditions sets the Applied and Available conditions and the health of the
+component. A component is Degraded while it is failing, Progressing while it is being applied or its
+deployments are not available, and Healthy otherwise.
+*/
+func (r *MultiClusterEngineReconciler) setInternalEngineComponentConditions(
+	iec *backplanev1.InternalEngineComponent, newStatus *backplanev1.InternalEngineComponentStatus,
+	result ctrl.Result, reconcileErr error) {
+	applied := metav1.Condition{
+		Type:               backplanev1.ComponentApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             status.DeploySuccessReason,
+		Message:            "All resources of the component are applied",
+		ObservedGeneration: iec.GetGeneration(),
+	}
+	failure, failing := r.StatusManager.GetFailure(iec.GetName())
+	switch {
+	case failing:
+		applied.Status = metav1.ConditionFalse
+		applied.Reason = failure.Reason
+		applied.Message = failure.Message
+	case reconcileErr != nil:
+		applied.Status = metav1.ConditionFalse
+		applied.Reason = status.DeployFailedReason
+		applied.Message = reconcileErr.Error()
+	case result != (ctrl.Result{}):
+		applied.Status = metav1.ConditionFalse
+		applied.Reason = status.ComponentsUpdatingReason
+		applied.Message = "Waiting for the resources of the component to be applied"
+	}
+	apimeta.SetStatusCondition(&newStatus.Conditions, applied)
+
+	unavailable := []string{}
+	for _, resource := range newStatus.AppliedResources {
+		if resource.Kind != "Deployment" {
+			continue
+		}
+		deployment := status.DeploymentStatus{
+			NamespacedName: types.NamespacedName{Name: resource.Name, Namespace: resource.Namespace},
+		}
+		if !deployment.Status(r.Client).Available {
+			unavailable = append(unavailable, resource.Name)
+		}
+	}
+	available := metav1.Condition{
+		Type:               backplanev1.ComponentAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             status.ComponentsAvailableReason,
+		Message:            "All deployments of the component are available",
+		ObservedGeneration: iec.GetGeneration(),
+	}
+	if len(unavailable) > 0 {
+		available.Status = metav1.ConditionFalse
+		available.Reason = status.ComponentsUnavailableReason
+		available.Message = fmt.Sprintf("Deployments are not available: %s", strings.Join(unavailable, ", "))
+	}
+	apimeta.SetStatusCondition(&newStatus.Conditions, available)
+
+	switch {
+	case applied.Status == metav1.ConditionFalse && applied.Reason != status.ComponentsUpdatingReason:
+		newStatus.Health = backplanev1.ComponentDegraded
+	case applied.Status == metav1.ConditionFalse || available.Status == metav1.ConditionFalse:
+		newStatus.Health = backplanev1.ComponentProgressing
+	default:
+		newStatus.Health = backplanev1.ComponentHealthy
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+/*
+InternalEngineComponentReconciler reconciles a single component of the MultiClusterEngine when its
+InternalEngineComponent or one of the resources labeled for the component changes, so that a change
+to one resource does not re-render and re-apply every component. The MultiClusterEngine controller
+remains responsible for the lifecycle of the components: it installs and removes them, creates and
+deletes their InternalEngineComponents and aggregates their status. Once a component is healthy and
+applied with the current configuration, the MultiClusterEngine controller leaves applying it to this
+controller (see handedOff). Components with their own install hooks are only applied by the
+MultiClusterEngine controller (see handOffSupported).
+*/
+type InternalEngineComponentReconciler struct {
+	Client client.Client
+	// Engine is the MultiClusterEngine reconciler whose component functions and caches are reused
+	Engine *MultiClusterEngineReconciler
+}
+
+// componentLabeled matches the resources labeled for a component.
+var componentLabeled = predicate.NewPredicateFuncs(func(o client.Object) bool {
+	_, ok := o.GetLabels()[utils.ComponentLabel]
+	return ok
+})
+
+// componentFromContext returns the component whose resources are applied or planned in the context, if any.
+func componentFromContext(ctx context.Context) string {
+	if scope, ok := inventoryFromContext(ctx); ok && scope.component != "" {
+		return scope.component
+	}
+	if pc, ok := planFromContext(ctx); ok {
+		return pc.component
+	}
+	return ""
+}
+
+// Reconcile applies the component of the InternalEngineComponent, if it is enabled and reconciled by the operator.
+func (r *InternalEngineComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	iec := &backplanev1.InternalEngineComponent{}
+	if err := r.Client.Get(ctx, req.NamespacedName, iec); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if iec.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	component, ok := lookupComponent(iec.GetName())
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	mce, err := r.multiClusterEngine(ctx, iec.GetNamespace())
+	if err != nil || mce == nil {
+		return ctrl.Result{}, err
+	}
+	if !r.reconcilable(ctx, mce, component) {
+		return ctrl.Result{}, nil
+	}
+
+	unlock := r.Engine.componentLocks.lock(component.GetName())
+	defer unlock()
+
+	// Images are resolved by the MultiClusterEngine controller
+	engine := r.Engine.componentReconciler()
+	if len(engine.CacheSpec.ImageOverrides) == 0 {
+		log.Info("Waiting for the MultiClusterEngine to be reconciled", "component", component.GetName())
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+	// A failing component keeps its backoff
+	if iec.Status.Failure != nil {
+		engine.StatusManager.SetFailure(*iec.Status.Failure)
+	}
+
+	log.Info("Reconciling component", "component", component.GetName())
+	ctx = withInventory(ctx, newResourceInventory(map[string][]inventoryEntry{}), "")
+	result, err := engine.applyComponent(ctx, component.GetName(), func(ctx context.Context) (ctrl.Result, error) {
+		return component.Enable(ctx, engine, mce)
+	})
+	if iecErr := engine.updateInternalEngineComponent(withInventoryComponent(ctx, component.GetName()), mce,
+		component.GetName(), result, err); iecErr != nil && err == nil {
+		return result, iecErr
+	}
+	return result, err
+}
+
+/*
+reconcilable reports whether the component is left to this controller. Components are not reconciled
+while the MultiClusterEngine is deleted, paused or in plan mode, and components with their own install
+hooks and components that are disabled, unsupported, externally managed or whose prerequisites are not
+met are left to the MultiClusterEngine controller.
+*/
+func (r *InternalEngineComponentReconciler) reconcilable(ctx context.Context, mce *backplanev1.MultiClusterEngine,
+	component Component) bool {
+	if mce.GetDeletionTimestamp() != nil || utils.IsPaused(mce) || utils.IsPlanMode(mce) {
+		return false
+	}
+	if !handOffSupported(component) || !component.IsSupported() || !mce.Enabled(component.GetName()) ||
+		len(r.Engine.externallyManagedNames(mce, component)) > 0 {
+		return false
+	}
+	canInstall, err := component.CanInstall(ctx, r.Engine, mce)
+	return err == nil && canInstall
+}
+
+// multiClusterEngine returns the MultiClusterEngine whose target namespace is the given namespace, if any.
+func (r *InternalEngineComponentReconciler) multiClusterEngine(ctx context.Context,
+	namespace string) (*backplanev1.MultiClusterEngine, error) {
+	mces := &backplanev1.MultiClusterEngineList{}
+	if err := r.Client.List(ctx, mces); err != nil {
+		return nil, err
+	}
+	for i := range mces.Items {
+		if mces.Items[i].Spec.TargetNamespace == namespace {
+			return &mces.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// componentRequests maps a resource labeled for a component to the InternalEngineComponent of the component.
+func (r *InternalEngineComponentReconciler) componentRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	labels := o.GetLabels()
+	mce := &backplanev1.MultiClusterEngine{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: labels["backplaneconfig.name"]}, mce); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to get MultiClusterEngine of component resource",
+				"Name", o.GetName(), "Namespace", o.GetNamespace())
+		}
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      labels[utils.ComponentLabel],
+		Namespace: mce.Spec.TargetNamespace,
+	}}}
+}
+
+/*
+componentWatches returns the kinds of the resources labeled for a component that are watched besides
+Deployments. Only their metadata is watched, as most of them are not cached by the client, and the
+informers of those are restricted to the labeled resources (see ComponentCacheByObject).
+*/
+func componentWatches() []client.Object {
+	return []client.Object{
+		&appsv1.StatefulSet{},
+		&corev1.Service{},
+		&corev1.ServiceAccount{},
+		&corev1.ConfigMap{},
+		&corev1.Secret{},
+		&rbacv1.Role{},
+		&rbacv1.RoleBinding{},
+		&rbacv1.ClusterRole{},
+		&rbacv1.ClusterRoleBinding{},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{},
+		&admissionregistrationv1.MutatingWebhookConfiguration{},
+	}
+}
+
+/*
+ComponentCacheByObject restricts the informers of the watched kinds that the client does not cache, given
+by uncached, to the resources labeled for a component, as only this controller reads them from the cache.
+*/
+func ComponentCacheByObject(uncached []client.Object) (map[client.Object]cache.ByObject, error) {
+	labeled, err := labels.NewRequirement(utils.ComponentLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	byObject := map[client.Object]cache.ByObject{}
+	for _, obj := range componentWatches() {
+		for _, u := range uncached {
+			if reflect.TypeOf(obj) == reflect.TypeOf(u) {
+				byObject[obj] = cache.ByObject{Label: labels.NewSelector().Add(*labeled)}
+			}
+		}
+	}
+	return byObject, nil
+}
+
+/*
+SetupWithManager sets up the controller with the Manager. Deployments are reconciled when their spec,
+labels or annotations change, and the other resources labeled for a component on any change, as most of
+them have no status.
+*/
+func (r *InternalEngineComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("internalenginecomponent").
+		For(&backplanev1.InternalEngineComponent{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(r.componentRequests),
+			builder.WithPredicates(componentLabeled, predicate.Or(predicate.GenerationChangedPredicate{},
+				predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})))
+	for _, obj := range componentWatches() {
+		b = b.Watches(obj, handler.EnqueueRequestsFromMapFunc(r.componentRequests), builder.OnlyMetadata,
+			builder.WithPredicates(componentLabeled, predicate.ResourceVersionChangedPredicate{}))
+	}
+	return b.Complete(r)
+}
@@ -0,0 +1,151 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"github.com/stolostron/backplane-operator/pkg/version"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_InternalEngineComponentReconciler_Reconcile(t *testing.T) {
+	saved := componentRegistry
+	defer func() { componentRegistry = saved }()
+
+	componentRegistry = []Component{}
+	internal := newFakeComponent("internal-component", nil)
+	RegisterComponent(internal)
+
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: "test-ns",
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{{Name: "internal-component", Enabled: true}},
+			},
+		},
+	}
+	iec := &backplanev1.InternalEngineComponent{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal-component", Namespace: "test-ns"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce, iec).
+		WithStatusSubresource(&backplanev1.InternalEngineComponent{}).Build()
+	engine := &MultiClusterEngineReconciler{Client: cl, Scheme: s, StatusManager: &status.StatusTracker{}}
+	engine.StatusManager.Reset("")
+	r := &InternalEngineComponentReconciler{Client: cl, Engine: engine}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "internal-component", Namespace: "test-ns"}}
+
+	// Images are not resolved until the MultiClusterEngine is reconciled
+	if result, err := r.Reconcile(ctx, req); err != nil || result.RequeueAfter == 0 || internal.enabled != 0 {
+		t.Fatalf("expected the component to wait for the MultiClusterEngine, got %v, %v, enabled=%d", result, err,
+			internal.enabled)
+	}
+
+	engine.CacheSpec.ImageOverrides = map[string]string{"image": "quay.io/image:1.0"}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() returned error: %v", err)
+	}
+	if internal.enabled != 1 {
+		t.Errorf("expected the component to be enabled once, got %d", internal.enabled)
+	}
+	got := &backplanev1.InternalEngineComponent{}
+	if err := cl.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get InternalEngineComponent: %v", err)
+	}
+	if got.Status.Health != backplanev1.ComponentHealthy || got.Status.LastAppliedVersion != version.Version {
+		t.Errorf("expected the component status to be recorded, got %+v", got.Status)
+	}
+
+	// Paused and disabled components are left to the MultiClusterEngine controller
+	mce.SetAnnotations(map[string]string{utils.AnnotationMCEPause: "true"})
+	if err := cl.Update(ctx, mce); err != nil {
+		t.Fatalf("failed to update MultiClusterEngine: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() returned error: %v", err)
+	}
+	mce.SetAnnotations(nil)
+	mce.Spec.Overrides.Components[0].Enabled = false
+	if err := cl.Update(ctx, mce); err != nil {
+		t.Fatalf("failed to update MultiClusterEngine: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() returned error: %v", err)
+	}
+	if internal.enabled != 1 || internal.disabled != 0 {
+		t.Errorf("expected paused and disabled components not to be reconciled, got enabled=%d disabled=%d",
+			internal.enabled, internal.disabled)
+	}
+}
+
+func Test_InternalEngineComponentReconciler_componentRequests(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	r := &InternalEngineComponentReconciler{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(mce).Build()}
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "discovery-operator", Namespace: "other-ns",
+		Labels: map[string]string{"backplaneconfig.name": mce.GetName(), utils.ComponentLabel: backplanev1.Discovery}}}
+	requests := r.componentRequests(context.Background(), deployment)
+	want := types.NamespacedName{Name: backplanev1.Discovery, Namespace: "test-ns"}
+	if len(requests) != 1 || requests[0].NamespacedName != want {
+		t.Errorf("expected a request for %v, got %v", want, requests)
+	}
+
+	deployment.Labels["backplaneconfig.name"] = "missing"
+	if requests := r.componentRequests(context.Background(), deployment); len(requests) != 0 {
+		t.Errorf("expected no request without a MultiClusterEngine, got %v", requests)
+	}
+}
+
+func Test_componentFromContext(t *testing.T) {
+	inventory := newResourceInventory(map[string][]inventoryEntry{})
+	ctx := withInventory(context.Background(), inventory, "")
+	if got := componentFromContext(withInventoryComponent(ctx, "internal-component")); got != "internal-component" {
+		t.Errorf("componentFromContext() = %s, want internal-component", got)
+	}
+	if got := componentFromContext(withPlan(context.Background(), newReconcilePlan(), "planned")); got != "planned" {
+		t.Errorf("componentFromContext() = %s, want planned", got)
+	}
+	if got := componentFromContext(ctx); got != "" {
+		t.Errorf("componentFromContext() = %s, want no component", got)
+	}
+}
+
+func Test_ComponentCacheByObject(t *testing.T) {
+	byObject, err := ComponentCacheByObject([]client.Object{&corev1.Secret{}, &corev1.ConfigMap{}, &corev1.Pod{}})
+	if err != nil {
+		t.Fatalf("ComponentCacheByObject() returned error: %v", err)
+	}
+	if len(byObject) != 2 {
+		t.Fatalf("expected only the watched kinds to be restricted, got %v", byObject)
+	}
+	for obj, config := range byObject {
+		labeled := labels.Set{utils.ComponentLabel: backplanev1.Discovery}
+		if !config.Label.Matches(labeled) || config.Label.Matches(labels.Set{}) {
+			t.Errorf("expected the informer of %T to be restricted to labeled resources, got %s", obj, config.Label)
+		}
+	}
+}
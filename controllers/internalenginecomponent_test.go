@@ -0,0 +1,314 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_updateInternalEngineComponent(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: "test-ns",
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{
+					{
+						Name:    backplanev1.Discovery,
+						Enabled: true,
+						ConfigOverrides: backplanev1.ConfigOverride{
+							Deployments: []backplanev1.DeploymentConfig{{Name: "discovery-operator"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	iec := &backplanev1.InternalEngineComponent{
+		ObjectMeta: metav1.ObjectMeta{Name: backplanev1.Discovery, Namespace: "test-ns"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce, iec).
+		WithStatusSubresource(&backplanev1.InternalEngineComponent{}).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: s, StatusManager: &status.StatusTracker{}}
+	r.StatusManager.Reset("")
+
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "discovery-operator", "namespace": "test-ns"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "manager", "image": "quay.io/discovery:1.0"},
+					},
+				},
+			},
+		},
+	}}
+
+	inventory := newResourceInventory(map[string][]inventoryEntry{})
+	ctx := withInventory(context.Background(), inventory, backplanev1.Discovery)
+	recordApplied(ctx, deployment)
+	inventory.markComplete(backplanev1.Discovery)
+	r.StatusManager.AddDrift(backplanev1.ResourceDrift{Kind: "Deployment", Namespace: "test-ns",
+		Name: "discovery-operator", Fields: []string{"spec.replicas"}, Overwritten: true})
+	r.StatusManager.AddDrift(backplanev1.ResourceDrift{Kind: "Deployment", Namespace: "test-ns",
+		Name: "hive-operator", Fields: []string{"spec.replicas"}, Overwritten: true})
+
+	if err := r.updateInternalEngineComponent(ctx, mce, backplanev1.Discovery, ctrl.Result{}, nil); err != nil {
+		t.Fatalf("updateInternalEngineComponent() returned error: %v", err)
+	}
+
+	got := &backplanev1.InternalEngineComponent{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: backplanev1.Discovery, Namespace: "test-ns"}, got); err != nil {
+		t.Fatalf("failed to get InternalEngineComponent: %v", err)
+	}
+	if !got.Spec.Enabled || len(got.Spec.ConfigOverrides.Deployments) != 1 {
+		t.Errorf("expected the spec to hold the component configuration, got %+v", got.Spec)
+	}
+	if got.Spec.Images["discovery-operator/manager"] != "quay.io/discovery:1.0" {
+		t.Errorf("expected the spec to hold the applied images, got %v", got.Spec.Images)
+	}
+	if len(got.Status.AppliedResources) != 1 || got.Status.AppliedResources[0].Name != "discovery-operator" {
+		t.Errorf("expected the status to list the applied resources, got %v", got.Status.AppliedResources)
+	}
+	if got.Status.LastAppliedVersion != version.Version {
+		t.Errorf("expected last applied version %s, got %s", version.Version, got.Status.LastAppliedVersion)
+	}
+	if configHash, _ := r.componentConfigHash(ctx, mce, backplanev1.Discovery); got.Status.ConfigHash != configHash {
+		t.Errorf("expected the status to hold the configuration hash, got %q", got.Status.ConfigHash)
+	}
+	if len(got.Status.DriftedResources) != 1 || got.Status.DriftedResources[0].Name != "discovery-operator" {
+		t.Errorf("expected the status to list the drift of the component, got %v", got.Status.DriftedResources)
+	}
+	if got.Status.Failure != nil {
+		t.Errorf("expected no failure, got %v", got.Status.Failure)
+	}
+	// The applied deployment does not exist, so it is not available yet
+	if got.Status.Health != backplanev1.ComponentProgressing {
+		t.Errorf("expected health %s, got %s", backplanev1.ComponentProgressing, got.Status.Health)
+	}
+	if !apimeta.IsStatusConditionTrue(got.Status.Conditions, backplanev1.ComponentApplied) ||
+		!apimeta.IsStatusConditionFalse(got.Status.Conditions, backplanev1.ComponentAvailable) {
+		t.Errorf("expected the component to be applied but unavailable, got %v", got.Status.Conditions)
+	}
+
+	// A failed reconcile degrades the component and keeps the resources last applied
+	failed := withInventory(context.Background(), newResourceInventory(map[string][]inventoryEntry{}),
+		backplanev1.Discovery)
+	r.StatusManager.SetFailure(backplanev1.ComponentFailure{Name: backplanev1.Discovery,
+		Reason: status.ApplyFailedReason, Message: "failed", Failures: 1})
+	if err := r.updateInternalEngineComponent(failed, mce, backplanev1.Discovery, ctrl.Result{},
+		errors.New("failed")); err != nil {
+		t.Fatalf("updateInternalEngineComponent() returned error: %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: backplanev1.Discovery, Namespace: "test-ns"}, got); err != nil {
+		t.Fatalf("failed to get InternalEngineComponent: %v", err)
+	}
+	if got.Status.Health != backplanev1.ComponentDegraded {
+		t.Errorf("expected health %s, got %s", backplanev1.ComponentDegraded, got.Status.Health)
+	}
+	if len(got.Status.AppliedResources) != 1 || len(got.Spec.Images) != 1 {
+		t.Errorf("expected the resources and images last applied to be kept, got %v and %v",
+			got.Status.AppliedResources, got.Spec.Images)
+	}
+	if got.Status.Failure == nil || got.Status.Failure.Failures != 1 {
+		t.Errorf("expected the status to hold the failure, got %v", got.Status.Failure)
+	}
+
+	// The failure is reported on the MultiClusterEngine, and kept by a reconciler tracking status on its own
+	r.StatusManager.Reset("")
+	r.adoptComponentFailure(context.Background(), mce, backplanev1.Discovery)
+	if _, failing := r.StatusManager.GetFailure(backplanev1.Discovery); !failing {
+		t.Error("expected the failure of the InternalEngineComponent to be adopted")
+	}
+	if engine := r.componentReconciler(); engine.StatusManager == r.StatusManager {
+		t.Error("expected the component reconciler to track status on its own")
+	}
+}
+
+func Test_componentConfigHash(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: "test-ns",
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{{
+					Name:    backplanev1.Discovery,
+					Enabled: true,
+					ConfigOverrides: backplanev1.ConfigOverride{Patches: []backplanev1.ResourcePatch{{
+						Target: backplanev1.PatchTarget{Kind: "Deployment", Name: "discovery-operator"},
+						Type:   backplanev1.StrategicMergePatchType,
+						ConfigMapRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "patches"}, Key: "discovery"},
+					}}},
+				}},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "patches", Namespace: "test-ns"},
+		Data:       map[string]string{"discovery": `{"metadata": {"labels": {"patched": "true"}}}`},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce, configMap).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: s}
+	ctx := context.Background()
+
+	hash, err := r.componentConfigHash(ctx, mce, backplanev1.Discovery)
+	if err != nil {
+		t.Fatalf("componentConfigHash() returned error: %v", err)
+	}
+	other, err := r.componentConfigHash(ctx, mce, backplanev1.Hive)
+	if err != nil || other == hash {
+		t.Errorf("expected the patches of other components not to be hashed, got %q, %v", other, err)
+	}
+
+	// The configuration changes with the patch read from the ConfigMap
+	configMap.Data["discovery"] = `{"metadata": {"labels": {"patched": "false"}}}`
+	if err := cl.Update(ctx, configMap); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	if changed, err := r.componentConfigHash(ctx, mce, backplanev1.Discovery); err != nil || changed == hash {
+		t.Errorf("expected the hash to change with the patch ConfigMap, got %q, %v", changed, err)
+	}
+
+	if err := cl.Delete(ctx, configMap); err != nil {
+		t.Fatalf("failed to delete ConfigMap: %v", err)
+	}
+	if _, err := r.componentConfigHash(ctx, mce, backplanev1.Discovery); err == nil {
+		t.Error("expected an error for a missing patch ConfigMap")
+	}
+}
+
+func Test_handedOff(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+		Status: backplanev1.MultiClusterEngineStatus{
+			Footprint: []backplanev1.ComponentFootprint{{Name: backplanev1.Discovery}},
+		},
+	}
+	cache := CacheSpec{ImageOverrides: map[string]string{"discovery_operator": "quay.io/discovery:1.0"}}
+	discovery, _ := lookupComponent(backplanev1.Discovery)
+	hive, _ := lookupComponent(backplanev1.Hive)
+	configHash, err := (&MultiClusterEngineReconciler{CacheSpec: cache}).componentConfigHash(context.Background(),
+		mce, backplanev1.Discovery)
+	if err != nil {
+		t.Fatalf("componentConfigHash() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		component Component
+		status    backplanev1.InternalEngineComponentStatus
+		failing   bool
+		want      bool
+	}{
+		{
+			name:      "healthy and applied with the current configuration",
+			component: discovery,
+			status: backplanev1.InternalEngineComponentStatus{Health: backplanev1.ComponentHealthy,
+				LastAppliedVersion: version.Version, ConfigHash: configHash},
+			want: true,
+		},
+		{
+			name:      "configuration changed",
+			component: discovery,
+			status: backplanev1.InternalEngineComponentStatus{Health: backplanev1.ComponentHealthy,
+				LastAppliedVersion: version.Version, ConfigHash: "stale"},
+		},
+		{
+			name:      "applied by another version",
+			component: discovery,
+			status: backplanev1.InternalEngineComponentStatus{Health: backplanev1.ComponentHealthy,
+				LastAppliedVersion: "0.0.1", ConfigHash: configHash},
+		},
+		{
+			name:      "progressing",
+			component: discovery,
+			status: backplanev1.InternalEngineComponentStatus{Health: backplanev1.ComponentProgressing,
+				LastAppliedVersion: version.Version, ConfigHash: configHash},
+		},
+		{
+			name:      "failing",
+			component: discovery,
+			status: backplanev1.InternalEngineComponentStatus{Health: backplanev1.ComponentHealthy,
+				LastAppliedVersion: version.Version, ConfigHash: configHash},
+			failing: true,
+		},
+		{
+			name:      "install hook",
+			component: hive,
+			status: backplanev1.InternalEngineComponentStatus{Health: backplanev1.ComponentHealthy,
+				LastAppliedVersion: version.Version, ConfigHash: configHash},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iec := &backplanev1.InternalEngineComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.component.GetName(), Namespace: "test-ns"},
+				Status:     tt.status,
+			}
+			cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce, iec).
+				WithStatusSubresource(&backplanev1.InternalEngineComponent{}).Build()
+			r := &MultiClusterEngineReconciler{Client: cl, Scheme: s, CacheSpec: cache,
+				StatusManager: &status.StatusTracker{}}
+			r.StatusManager.Reset("")
+			if tt.failing {
+				r.StatusManager.SetFailure(backplanev1.ComponentFailure{Name: tt.component.GetName(),
+					Reason: status.DeployFailedReason})
+			}
+
+			if _, got := r.handedOff(context.Background(), mce, tt.component); got != tt.want {
+				t.Errorf("handedOff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !handOffSupported(discovery) || handOffSupported(hive) {
+		t.Error("expected only components without install hooks to support being handed off")
+	}
+
+	t.Run("keeps the recorded status", func(t *testing.T) {
+		r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+		r.StatusManager.Reset("")
+		r.reportHandedOff(mce, discovery, &backplanev1.InternalEngineComponent{
+			Status: backplanev1.InternalEngineComponentStatus{DriftedResources: []backplanev1.ResourceDrift{
+				{Kind: "Deployment", Namespace: "test-ns", Name: "discovery-operator", Overwritten: true}}},
+		})
+		if _, ok := r.StatusManager.Footprint[backplanev1.Discovery]; !ok {
+			t.Errorf("expected the footprint of the component to be kept, got %v", r.StatusManager.Footprint)
+		}
+		if len(r.StatusManager.Components) == 0 {
+			t.Error("expected the deployments of the component to be tracked")
+		}
+		if len(r.StatusManager.GetDrift()) != 1 {
+			t.Errorf("expected the drift of the component to be reported, got %v", r.StatusManager.GetDrift())
+		}
+	})
+}
@@ -0,0 +1,367 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// inventoryConfigMapName is the ConfigMap in the target namespace that records the resources applied per component.
+const inventoryConfigMapName = "multicluster-engine-inventory"
+
+// inventoryEntry identifies a resource applied by the operator.
+type inventoryEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+func (e inventoryEntry) String() string {
+	return fmt.Sprintf("%s/%s/%s/%s", e.APIVersion, e.Kind, e.Namespace, e.Name)
+}
+
+/*
+resourceInventory tracks the resources applied per component. The previous inventory is loaded from the
+inventory ConfigMap, and the resources applied during the reconcile are recorded as they are applied.
+Components may be reconciled concurrently.
+*/
+type resourceInventory struct {
+	mu       sync.Mutex
+	previous map[string][]inventoryEntry
+	applied  map[string]map[string]inventoryEntry
+	// images maps the containers of the workloads applied per component, as <workload>/<container>, to their image
+	images map[string]map[string]string
+	// complete lists the components that were fully applied, whose previous resources can be pruned
+	complete map[string]bool
+}
+
+type inventoryContextKey struct{}
+
+// inventoryScope is stored in the context so that applyTemplate records resources against the component.
+type inventoryScope struct {
+	inventory *resourceInventory
+	component string
+}
+
+func newResourceInventory(previous map[string][]inventoryEntry) *resourceInventory {
+	return &resourceInventory{
+		previous: previous,
+		applied:  map[string]map[string]inventoryEntry{},
+		images:   map[string]map[string]string{},
+		complete: map[string]bool{},
+	}
+}
+
+// withInventory returns a context in which applied resources are recorded against the component.
+func withInventory(ctx context.Context, inventory *resourceInventory, component string) context.Context {
+	return context.WithValue(ctx, inventoryContextKey{}, inventoryScope{inventory: inventory, component: component})
+}
+
+// inventoryFromContext returns the inventory scope of the context, if any.
+func inventoryFromContext(ctx context.Context) (inventoryScope, bool) {
+	scope, ok := ctx.Value(inventoryContextKey{}).(inventoryScope)
+	return scope, ok
+}
+
+// withInventoryComponent scopes the inventory of the context, if any, to the component.
+func withInventoryComponent(ctx context.Context, component string) context.Context {
+	if scope, ok := inventoryFromContext(ctx); ok {
+		return withInventory(ctx, scope.inventory, component)
+	}
+	return ctx
+}
+
+// recordApplied records a resource applied for the component of the context.
+func recordApplied(ctx context.Context, u *unstructured.Unstructured) {
+	scope, ok := inventoryFromContext(ctx)
+	if !ok || scope.component == "" {
+		return
+	}
+	entry := inventoryEntry{APIVersion: u.GetAPIVersion(), Kind: u.GetKind(), Namespace: u.GetNamespace(),
+		Name: u.GetName()}
+
+	scope.inventory.mu.Lock()
+	defer scope.inventory.mu.Unlock()
+	if scope.inventory.applied[scope.component] == nil {
+		scope.inventory.applied[scope.component] = map[string]inventoryEntry{}
+	}
+	scope.inventory.applied[scope.component][entry.String()] = entry
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", field)
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(container, "name")
+			image, _, _ := unstructured.NestedString(container, "image")
+			if name == "" || image == "" {
+				continue
+			}
+			if scope.inventory.images[scope.component] == nil {
+				scope.inventory.images[scope.component] = map[string]string{}
+			}
+			scope.inventory.images[scope.component][u.GetName()+"/"+name] = image
+		}
+	}
+}
+
+// markComplete records that every resource of the component was applied in this reconcile.
+func (inv *resourceInventory) markComplete(component string) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	inv.complete[component] = true
+}
+
+// isComplete reports whether every resource of the component was applied in this reconcile.
+func (inv *resourceInventory) isComplete(component string) bool {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	return inv.complete[component]
+}
+
+// appliedResources returns the resources applied for the component in this reconcile, sorted.
+func (inv *resourceInventory) appliedResources(component string) []backplanev1.AppliedResource {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	resources := []backplanev1.AppliedResource{}
+	for _, key := range slices.Sorted(maps.Keys(inv.applied[component])) {
+		entry := inv.applied[component][key]
+		resources = append(resources, backplanev1.AppliedResource{APIVersion: entry.APIVersion, Kind: entry.Kind,
+			Namespace: entry.Namespace, Name: entry.Name})
+	}
+	return resources
+}
+
+// appliedImages returns the images of the workloads applied for the component in this reconcile.
+func (inv *resourceInventory) appliedImages(component string) map[string]string {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	return maps.Clone(inv.images[component])
+}
+
+/*
+applyComponent reconciles the named component with backoff and records the resources it applies in the
+inventory of the context. The component is marked complete when it was reconciled without an error or a
+requeue, so that resources it no longer applies can be pruned.
+*/
+func (r *MultiClusterEngineReconciler) applyComponent(ctx context.Context, name string,
+	fn func(ctx context.Context) (ctrl.Result, error)) (ctrl.Result, error) {
+	ctx = withInventoryComponent(ctx, name)
+	result, err := r.reconcileWithBackoff(name, func() (ctrl.Result, error) {
+		return fn(ctx)
+	})
+	if scope, ok := inventoryFromContext(ctx); ok && err == nil && result == (ctrl.Result{}) {
+		scope.inventory.markComplete(name)
+	}
+	return result, err
+}
+
+/*
+reconcile returns the inventory to store and the resources to prune. Resources of completely applied
+components that are in the previous inventory but were not applied again are pruned. The previous
+inventory of the other components is kept, together with what was applied for them.
+*/
+func (inv *resourceInventory) reconcile() (map[string][]inventoryEntry, []inventoryEntry) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	next := map[string][]inventoryEntry{}
+	orphans := []inventoryEntry{}
+	components := map[string]bool{}
+	for c := range inv.previous {
+		components[c] = true
+	}
+	applied := map[string]bool{}
+	for c, entries := range inv.applied {
+		components[c] = true
+		for key := range entries {
+			applied[key] = true
+		}
+	}
+
+	for _, component := range slices.Sorted(maps.Keys(components)) {
+		entries := maps.Clone(inv.applied[component])
+		if entries == nil {
+			entries = map[string]inventoryEntry{}
+		}
+		for _, entry := range inv.previous[component] {
+			if _, ok := entries[entry.String()]; ok {
+				continue
+			}
+			// Resources that moved to another component are not orphaned
+			if applied[entry.String()] {
+				continue
+			}
+			if inv.complete[component] {
+				orphans = append(orphans, entry)
+			} else {
+				entries[entry.String()] = entry
+			}
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(entries)) {
+			next[component] = append(next[component], entries[key])
+		}
+	}
+	return next, orphans
+}
+
+// previousComponent returns the component the resource was applied for in the previous reconcile.
+func (inv *resourceInventory) previousComponent(entry inventoryEntry) string {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	for _, component := range slices.Sorted(maps.Keys(inv.previous)) {
+		if slices.Contains(inv.previous[component], entry) {
+			return component
+		}
+	}
+	return ""
+}
+
+// loadInventory reads the resources applied per component in the previous reconcile.
+func (r *MultiClusterEngineReconciler) loadInventory(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine) (map[string][]inventoryEntry, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: inventoryConfigMapName,
+		Namespace: backplaneConfig.Spec.TargetNamespace}, cm)
+	if apierrors.IsNotFound(err) {
+		return map[string][]inventoryEntry{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	inventory := map[string][]inventoryEntry{}
+	for component, data := range cm.Data {
+		entries := []inventoryEntry{}
+		if err := yaml.Unmarshal([]byte(data), &entries); err != nil {
+			return nil, fmt.Errorf("failed to read inventory of component %s: %w", component, err)
+		}
+		inventory[component] = entries
+	}
+	return inventory, nil
+}
+
+/*
+pruneInventory deletes the resources that were applied in a previous reconcile but are no longer
+rendered, and stores the updated inventory. Only resources labeled as belonging to this
+MultiClusterEngine are deleted.
+*/
+func (r *MultiClusterEngineReconciler) pruneInventory(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, inventory *resourceInventory) (ctrl.Result, error) {
+	next, orphans := inventory.reconcile()
+
+	errs := []string{}
+	for _, entry := range orphans {
+		if err := r.pruneResource(ctx, backplaneConfig, entry); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", entry, err.Error()))
+			// Keep the resource in the inventory so that it is pruned on the next reconcile
+			for component := range inventory.previous {
+				if slices.Contains(inventory.previous[component], entry) {
+					next[component] = append(next[component], entry)
+				}
+			}
+		}
+	}
+
+	if err := r.writeInventory(ctx, backplaneConfig, next); err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(errs) > 0 {
+		return ctrl.Result{}, fmt.Errorf("failed to prune resources: %s", strings.Join(errs, "; "))
+	}
+	return ctrl.Result{}, nil
+}
+
+// pruneResource deletes a resource that is no longer rendered, if it is labeled as belonging to this MultiClusterEngine.
+func (r *MultiClusterEngineReconciler) pruneResource(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, entry inventoryEntry) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(entry.APIVersion)
+	obj.SetKind(entry.Kind)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: entry.Name, Namespace: entry.Namespace}, obj)
+	if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if obj.GetLabels()["backplaneconfig.name"] != backplaneConfig.GetName() {
+		return nil
+	}
+
+	// In plan mode the deletion is only dry-run and recorded in the plan
+	if pc, planning := planFromContext(ctx); planning {
+		if err := r.Client.Delete(ctx, obj, client.DryRunAll); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		pc.recordDelete(obj)
+		return nil
+	}
+
+	log.Info("Pruning resource that is no longer rendered", "Kind", entry.Kind, "Name", entry.Name,
+		"Namespace", entry.Namespace)
+	if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// writeInventory creates or replaces the inventory ConfigMap in the target namespace.
+func (r *MultiClusterEngineReconciler) writeInventory(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, inventory map[string][]inventoryEntry) error {
+	data := map[string]string{}
+	for component, entries := range inventory {
+		out, err := yaml.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		data[component] = string(out)
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: inventoryConfigMapName,
+		Namespace: backplaneConfig.Spec.TargetNamespace}, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inventoryConfigMapName,
+				Namespace: backplaneConfig.Spec.TargetNamespace,
+			},
+			Data: data,
+		}
+		if err := ctrl.SetControllerReference(backplaneConfig, cm, r.Scheme); err != nil {
+			return err
+		}
+		log.Info("Creating inventory configmap", "Name", cm.GetName(), "Namespace", cm.GetNamespace())
+		return r.Client.Create(ctx, cm)
+	}
+
+	if maps.Equal(cm.Data, data) {
+		return nil
+	}
+	cm.Data = data
+	return r.Client.Update(ctx, cm)
+}
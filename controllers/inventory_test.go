@@ -0,0 +1,118 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func inventoryConfigMap(name string) inventoryEntry {
+	return inventoryEntry{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test-ns", Name: name}
+}
+
+func Test_resourceInventory_reconcile(t *testing.T) {
+	inventory := newResourceInventory(map[string][]inventoryEntry{
+		"complete":   {inventoryConfigMap("kept"), inventoryConfigMap("orphaned"), inventoryConfigMap("moved")},
+		"incomplete": {inventoryConfigMap("pending")},
+	})
+
+	ctx := withInventory(context.Background(), inventory, "")
+	configMap := func(name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("test-ns")
+		u.SetName(name)
+		return u
+	}
+	recordApplied(withInventoryComponent(ctx, "complete"), configMap("kept"))
+	recordApplied(withInventoryComponent(ctx, "other"), configMap("moved"))
+	inventory.markComplete("complete")
+
+	next, orphans := inventory.reconcile()
+	if len(orphans) != 1 || orphans[0] != inventoryConfigMap("orphaned") {
+		t.Errorf("expected only the orphaned resource to be pruned, got %v", orphans)
+	}
+	if len(next["complete"]) != 1 || next["complete"][0] != inventoryConfigMap("kept") {
+		t.Errorf("expected the complete component to keep only the applied resource, got %v", next["complete"])
+	}
+	if len(next["incomplete"]) != 1 || next["incomplete"][0] != inventoryConfigMap("pending") {
+		t.Errorf("expected the incomplete component to keep its previous inventory, got %v", next["incomplete"])
+	}
+	if len(next["other"]) != 1 || next["other"][0] != inventoryConfigMap("moved") {
+		t.Errorf("expected the moved resource to be recorded for its new component, got %v", next["other"])
+	}
+}
+
+func Test_applyComponent(t *testing.T) {
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	r.StatusManager.Reset("")
+	inventory := newResourceInventory(map[string][]inventoryEntry{})
+	ctx := withInventory(context.Background(), inventory, "")
+
+	_, _ = r.applyComponent(ctx, "applied", func(context.Context) (ctrl.Result, error) {
+		return ctrl.Result{}, nil
+	})
+	_, _ = r.applyComponent(ctx, "requeued", func(context.Context) (ctrl.Result, error) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	})
+	_, _ = r.applyComponent(ctx, "failed", func(context.Context) (ctrl.Result, error) {
+		return ctrl.Result{}, errors.New("failed")
+	})
+
+	if !inventory.complete["applied"] || inventory.complete["requeued"] || inventory.complete["failed"] {
+		t.Errorf("expected only the successfully applied component to be complete, got %v", inventory.complete)
+	}
+}
+
+func Test_pruneInventory(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	owned := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: "test-ns",
+		Labels: map[string]string{"backplaneconfig.name": mce.GetName()}}}
+	unowned := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unowned", Namespace: "test-ns"}}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce, owned, unowned).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: s}
+
+	ctx := context.Background()
+	inventory := newResourceInventory(map[string][]inventoryEntry{
+		"component": {inventoryConfigMap("owned"), inventoryConfigMap("unowned"), inventoryConfigMap("missing")},
+	})
+	inventory.markComplete("component")
+	if _, err := r.pruneInventory(ctx, mce, inventory); err != nil {
+		t.Fatalf("pruneInventory() returned error: %v", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "owned", Namespace: "test-ns"}, cm); err == nil {
+		t.Errorf("expected the labeled orphaned resource to be pruned")
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "unowned", Namespace: "test-ns"}, cm); err != nil {
+		t.Errorf("expected the resource without the backplaneconfig label to be kept: %v", err)
+	}
+
+	previous, err := r.loadInventory(ctx, mce)
+	if err != nil {
+		t.Fatalf("loadInventory() returned error: %v", err)
+	}
+	if len(previous["component"]) != 0 {
+		t.Errorf("expected the pruned resources to be removed from the inventory, got %v", previous)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package mcewebhook
+
+import (
+	"os"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/controllers"
+	"github.com/stolostron/backplane-operator/pkg/overrides"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+/*
+ComponentFootprint returns the footprint the component would have once the MultiClusterEngine is applied. The
+manifests of the MultiClusterEngine are rendered with the images and template overrides of the operator
+environment on the OpenShift version the operator detected, and the footprint is computed from the resources rendered for the component.
+*/
+func ComponentFootprint(mce *backplanev1.MultiClusterEngine, component string) (backplanev1.ComponentFootprint,
+	error) {
+	images := overrides.GetOverridesFromEnv(overrides.OperandImagePrefix)
+	if len(images) == 0 {
+		images = overrides.GetOverridesFromEnv(overrides.OSBSImagePrefix)
+	}
+	templateOverrides := overrides.GetOverridesFromEnv(overrides.TemplateOverridePrefix)
+	manifests, err := controllers.RenderManifests(mce, images, templateOverrides, os.Getenv("ACM_HUB_OCP_VERSION"))
+	if err != nil {
+		return backplanev1.ComponentFootprint{}, err
+	}
+
+	templates := []*unstructured.Unstructured{}
+	for _, manifest := range manifests {
+		if manifest.GetLabels()[utils.ComponentLabel] == component {
+			templates = append(templates, manifest)
+		}
+	}
+	return renderer.Footprint(component, templates)
+}
@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package mcewebhook
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComponentFootprint(t *testing.T) {
+	saved := renderer.TemplateFS()
+	renderer.SetTemplateFS(os.DirFS("../.."))
+	t.Cleanup(func() { renderer.SetTemplateFS(saved) })
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+	for _, image := range utils.GetTestImages() {
+		t.Setenv("OPERAND_IMAGE_"+strings.ToUpper(image), "quay.io/test/test:test")
+	}
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: "multicluster-engine",
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{{Name: backplanev1.Discovery, Enabled: true}},
+			},
+		},
+	}
+	footprint, err := ComponentFootprint(mce, backplanev1.Discovery)
+	if err != nil {
+		t.Fatalf("ComponentFootprint() returned error: %v", err)
+	}
+	if footprint.Name != backplanev1.Discovery || footprint.Deployments == 0 {
+		t.Errorf("expected the deployments of %s to be counted, got %+v", backplanev1.Discovery, footprint)
+	}
+	if memory := footprint.Requests[corev1.ResourceMemory]; memory.IsZero() {
+		t.Errorf("expected the memory requests of %s to be counted, got %v", backplanev1.Discovery, footprint.Requests)
+	}
+}
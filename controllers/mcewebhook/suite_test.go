@@ -21,7 +21,9 @@ package mcewebhook
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -44,6 +46,7 @@ import (
 	v1 "github.com/stolostron/backplane-operator/api/v1"
 	// "github.com/stolostron/backplane-operator/pkg/controllers"
 	"github.com/stolostron/backplane-operator/controllers"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/status"
 	"github.com/stolostron/backplane-operator/pkg/utils"
 
@@ -75,6 +78,17 @@ var webhookReconciler Reconciler
 
 // var reconciler controllers.MultiClusterEngineReconciler
 
+// unitTestTemplates serves the templates of the repository at its root, with the CRDs used by the unit
+// tests in place of the CRDs of the components.
+type unitTestTemplates string
+
+func (root unitTestTemplates) Open(name string) (fs.File, error) {
+	if rest, ok := strings.CutPrefix(name, "pkg/templates/crds"); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+		return os.DirFS(path.Join(string(root), "test/unit-test-crds")).Open(path.Join(".", rest))
+	}
+	return os.DirFS(string(root)).Open(name)
+}
+
 var _ = BeforeSuite(func() {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 
@@ -144,8 +158,7 @@ var _ = BeforeSuite(func() {
 	err = os.Setenv("POD_NAMESPACE", "default")
 	Expect(err).NotTo(HaveOccurred())
 
-	err = os.Setenv("DIRECTORY_OVERRIDE", "../../")
-	Expect(err).NotTo(HaveOccurred())
+	renderer.SetTemplateFS(unitTestTemplates("../.."))
 
 	err = os.Setenv("UNIT_TEST", "true")
 	Expect(err).NotTo(HaveOccurred())
@@ -203,8 +216,6 @@ var _ = AfterSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	err = os.Unsetenv("UNIT_TEST")
 	Expect(err).NotTo(HaveOccurred())
-	err = os.Unsetenv("DIRECTORY_OVERRIDE")
-	Expect(err).NotTo(HaveOccurred())
 
 	cancel()
 	By("tearing down the test environment")
@@ -7,7 +7,6 @@ import (
 	"fmt"
 
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
-	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -86,7 +85,7 @@ func (r *MultiClusterEngineReconciler) ensureNetworkPolicies(
 			continue
 		}
 
-		templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+		templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 		if len(errs) > 0 {
 			// Rendering errors are non-fatal - component may not have NetworkPolicy template yet
@@ -118,6 +117,7 @@ func (r *MultiClusterEngineReconciler) ensureNetworkPolicies(
 			if errors.IsNotFound(err) {
 				// Create NetworkPolicy - create-once pattern
 				applyReleaseVersionAnnotation(npTemplate)
+				setTemplateHash(npTemplate)
 				if err := r.Client.Create(ctx, npTemplate); err != nil {
 					return ctrl.Result{}, fmt.Errorf(
 						"failed to create NetworkPolicy %s/%s: %w",
@@ -0,0 +1,111 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+/*
+applyComponentPatches applies the patches of the component, in order, to the rendered templates they
+target. A patch is read from the component config or from the referenced ConfigMap in the target namespace.
+*/
+func (r *MultiClusterEngineReconciler) applyComponentPatches(ctx context.Context, mce *backplanev1.MultiClusterEngine,
+	templates []*unstructured.Unstructured, componentConfig backplanev1.ComponentConfig) error {
+	for i, patch := range componentConfig.ConfigOverrides.Patches {
+		raw, err := r.resolvePatch(ctx, mce, patch)
+		if err != nil {
+			return fmt.Errorf("failed to read patch %d of %s: %w", i, componentConfig.Name, err)
+		}
+
+		matched := false
+		for _, template := range templates {
+			if template.GetKind() != patch.Target.Kind || template.GetName() != patch.Target.Name {
+				continue
+			}
+			matched = true
+			if err := patchTemplate(template, patch.Type, raw); err != nil {
+				return fmt.Errorf("failed to apply patch %d of %s to %s %s: %w", i, componentConfig.Name,
+					template.GetKind(), template.GetName(), err)
+			}
+		}
+		if !matched {
+			log.Info("No rendered resource matches the patch", "Component", componentConfig.Name,
+				"Kind", patch.Target.Kind, "Name", patch.Target.Name)
+		}
+	}
+	return nil
+}
+
+// resolvePatch returns the patch as JSON, reading it from its ConfigMap if it references one.
+func (r *MultiClusterEngineReconciler) resolvePatch(ctx context.Context, mce *backplanev1.MultiClusterEngine,
+	patch backplanev1.ResourcePatch) ([]byte, error) {
+	if patch.ConfigMapRef == nil {
+		return backplanev1.DecodePatch(patch.Type, patch.Patch)
+	}
+	if r.Client == nil {
+		return nil, fmt.Errorf("the patch in ConfigMap %s cannot be read without a cluster", patch.ConfigMapRef.Name)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: patch.ConfigMapRef.Name,
+		Namespace: mce.Spec.TargetNamespace}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", mce.Spec.TargetNamespace,
+			patch.ConfigMapRef.Name, err)
+	}
+	data, ok := configMap.Data[patch.ConfigMapRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s/%s has no key %s", mce.Spec.TargetNamespace, patch.ConfigMapRef.Name,
+			patch.ConfigMapRef.Key)
+	}
+	return backplanev1.DecodePatch(patch.Type, data)
+}
+
+/*
+patchTemplate applies the JSON patch to the template. Strategic merge patches of kinds without strategic
+merge metadata, such as custom resources, are applied as JSON merge patches.
+*/
+func patchTemplate(template *unstructured.Unstructured, patchType backplanev1.PatchType, patch []byte) error {
+	original, err := json.Marshal(template.Object)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	switch patchType {
+	case backplanev1.JSON6902PatchType:
+		operations, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return err
+		}
+		if patched, err = operations.Apply(original); err != nil {
+			return err
+		}
+	default:
+		if dataStruct, err := clientgoscheme.Scheme.New(template.GroupVersionKind()); err == nil {
+			patched, err = strategicpatch.StrategicMergePatch(original, patch, dataStruct)
+			if err != nil {
+				return err
+			}
+		} else if patched, err = jsonpatch.MergePatch(original, patch); err != nil {
+			return err
+		}
+	}
+
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(patched, &object); err != nil {
+		return err
+	}
+	template.Object = object
+	return nil
+}
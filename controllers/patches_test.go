@@ -0,0 +1,126 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_applyComponentPatches(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	templates := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			{Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"name": "test", "namespace": "test-ns"},
+				"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "manager", "image": "manager:test"},
+						map[string]interface{}{"name": "proxy", "image": "proxy:test"},
+					},
+				}}},
+			}},
+			{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   map[string]interface{}{"name": "test", "namespace": "test-ns"},
+			}},
+			{Object: map[string]interface{}{
+				"apiVersion": "addon.open-cluster-management.io/v1alpha1",
+				"kind":       "ClusterManagementAddOn",
+				"metadata":   map[string]interface{}{"name": "test"},
+				"spec":       map[string]interface{}{"installStrategy": map[string]interface{}{"type": "Manual"}},
+			}},
+		}
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "patches", Namespace: "test-ns"},
+		Data:       map[string]string{"service": "- op: add\n  path: /metadata/annotations\n  value: {patched: \"true\"}\n"},
+	}
+	r := &MultiClusterEngineReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap).Build(),
+	}
+
+	config := backplanev1.ComponentConfig{Name: "test", ConfigOverrides: backplanev1.ConfigOverride{
+		Patches: []backplanev1.ResourcePatch{
+			{
+				Target: backplanev1.PatchTarget{Kind: "Deployment", Name: "test"},
+				Type:   backplanev1.StrategicMergePatchType,
+				Patch: `spec:
+  template:
+    spec:
+      containers:
+      - name: proxy
+        args: ["--verbose"]`,
+			},
+			{
+				Target: backplanev1.PatchTarget{Kind: "Service", Name: "test"},
+				Type:   backplanev1.JSON6902PatchType,
+				ConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "patches"}, Key: "service"},
+			},
+			{
+				Target: backplanev1.PatchTarget{Kind: "ClusterManagementAddOn", Name: "test"},
+				Type:   backplanev1.StrategicMergePatchType,
+				Patch:  `{"spec": {"installStrategy": {"type": "Placements"}}}`,
+			},
+			{
+				Target: backplanev1.PatchTarget{Kind: "Deployment", Name: "other"},
+				Type:   backplanev1.StrategicMergePatchType,
+				Patch:  `{"metadata": {"labels": {"unmatched": "true"}}}`,
+			},
+		},
+	}}
+
+	rendered := templates()
+	if err := r.applyComponentPatches(context.Background(), mce, rendered, config); err != nil {
+		t.Fatalf("applyComponentPatches() returned error: %v", err)
+	}
+
+	containers, _, _ := unstructured.NestedSlice(rendered[0].Object, "spec", "template", "spec", "containers")
+	if len(containers) != 2 {
+		t.Fatalf("expected the containers to be merged by name, got %v", containers)
+	}
+	proxy := containers[1].(map[string]interface{})
+	if proxy["image"] != "proxy:test" || proxy["args"] == nil {
+		t.Errorf("expected the args of the proxy container to be patched, got %v", proxy)
+	}
+	if rendered[1].GetAnnotations()["patched"] != "true" {
+		t.Errorf("expected the service to be patched from the ConfigMap, got %v", rendered[1].GetAnnotations())
+	}
+	if strategy, _, _ := unstructured.NestedString(rendered[2].Object, "spec", "installStrategy",
+		"type"); strategy != "Placements" {
+		t.Errorf("expected the custom resource to be merge patched, got %s", strategy)
+	}
+
+	config.ConfigOverrides.Patches = []backplanev1.ResourcePatch{{
+		Target: backplanev1.PatchTarget{Kind: "Service", Name: "test"},
+		Type:   backplanev1.JSON6902PatchType,
+		ConfigMapRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "patches"}, Key: "missing"},
+	}}
+	if err := r.applyComponentPatches(context.Background(), mce, templates(), config); err == nil {
+		t.Errorf("expected an error for a missing ConfigMap key")
+	}
+
+	config.ConfigOverrides.Patches = []backplanev1.ResourcePatch{{
+		Target: backplanev1.PatchTarget{Kind: "Service", Name: "test"},
+		Type:   backplanev1.JSON6902PatchType,
+		Patch:  "- op: remove\n  path: /spec/missing\n",
+	}}
+	if err := r.applyComponentPatches(context.Background(), mce, templates(), config); err == nil {
+		t.Errorf("expected an error for a patch that does not apply")
+	}
+}
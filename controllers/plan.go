@@ -0,0 +1,365 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// planConfigMapName is the ConfigMap in the target namespace that holds the changes found in plan mode.
+	planConfigMapName = "multicluster-engine-plan"
+	// planCRDsComponent is the name the changes to the CRDs are planned under.
+	planCRDsComponent = "crds"
+)
+
+// componentPlan lists the resources of a component that would be created, updated or deleted.
+type componentPlan struct {
+	Create []string `json:"create,omitempty"`
+	Update []string `json:"update,omitempty"`
+	Delete []string `json:"delete,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// reconcilePlan collects the planned changes of every component. Components may be planned concurrently.
+type reconcilePlan struct {
+	mu         sync.Mutex
+	components map[string]*componentPlan
+}
+
+type planContextKey struct{}
+
+// plannedComponent is stored in the context to make applyTemplate and deleteTemplate dry-run their changes.
+type plannedComponent struct {
+	plan      *reconcilePlan
+	component string
+}
+
+func newReconcilePlan() *reconcilePlan {
+	return &reconcilePlan{components: map[string]*componentPlan{}}
+}
+
+// withPlan returns a context in which the resource changes are recorded against the component instead of applied.
+func withPlan(ctx context.Context, plan *reconcilePlan, component string) context.Context {
+	return context.WithValue(ctx, planContextKey{}, plannedComponent{plan: plan, component: component})
+}
+
+// planFromContext returns the component being planned, if the context is in plan mode.
+func planFromContext(ctx context.Context) (plannedComponent, bool) {
+	p, ok := ctx.Value(planContextKey{}).(plannedComponent)
+	return p, ok
+}
+
+func (p *reconcilePlan) get(component string) *componentPlan {
+	if _, ok := p.components[component]; !ok {
+		p.components[component] = &componentPlan{}
+	}
+	return p.components[component]
+}
+
+func (pc plannedComponent) recordCreate(u *unstructured.Unstructured) {
+	pc.plan.mu.Lock()
+	defer pc.plan.mu.Unlock()
+	c := pc.plan.get(pc.component)
+	c.Create = append(c.Create, planResourceID(u))
+}
+
+func (pc plannedComponent) recordUpdate(u *unstructured.Unstructured) {
+	pc.plan.mu.Lock()
+	defer pc.plan.mu.Unlock()
+	c := pc.plan.get(pc.component)
+	c.Update = append(c.Update, planResourceID(u))
+}
+
+func (pc plannedComponent) recordDelete(u *unstructured.Unstructured) {
+	pc.plan.mu.Lock()
+	defer pc.plan.mu.Unlock()
+	c := pc.plan.get(pc.component)
+	c.Delete = append(c.Delete, planResourceID(u))
+}
+
+func (pc plannedComponent) recordError(err error) {
+	pc.plan.recordError(pc.component, err)
+}
+
+// recordError records that the changes of the component could not be planned.
+func (p *reconcilePlan) recordError(component string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.get(component).Error = err.Error()
+}
+
+// failed reports whether the changes of the component could not be fully planned.
+func (p *reconcilePlan) failed(component string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c, ok := p.components[component]
+	return ok && c.Error != ""
+}
+
+// planResourceID identifies a resource in the plan as Kind/namespace/name, or Kind/name if cluster scoped.
+func planResourceID(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", u.GetKind(), u.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", u.GetKind(), u.GetNamespace(), u.GetName())
+}
+
+/*
+planResourceChanged reports whether the dry-run result differs from the existing resource, ignoring the
+metadata maintained by the API server.
+*/
+func planResourceChanged(existing, planned *unstructured.Unstructured) bool {
+	strip := func(u *unstructured.Unstructured) map[string]interface{} {
+		obj := u.DeepCopy()
+		obj.SetManagedFields(nil)
+		obj.SetResourceVersion("")
+		obj.SetGeneration(0)
+		return obj.Object
+	}
+	return !equality.Semantic.DeepEqual(strip(existing), strip(planned))
+}
+
+// summary returns the number of resources that would be created, updated and deleted.
+func (p *reconcilePlan) summary() (creates, updates, deletes int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, c := range p.components {
+		creates += len(c.Create)
+		updates += len(c.Update)
+		deletes += len(c.Delete)
+	}
+	return creates, updates, deletes
+}
+
+// configMapData returns the plan of every component with changes or errors, keyed by component name.
+func (p *reconcilePlan) configMapData() (map[string]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data := map[string]string{}
+	for name, c := range p.components {
+		if len(c.Create) == 0 && len(c.Update) == 0 && len(c.Delete) == 0 && c.Error == "" {
+			continue
+		}
+		sort.Strings(c.Create)
+		sort.Strings(c.Update)
+		sort.Strings(c.Delete)
+		out, err := yaml.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		data[name] = string(out)
+	}
+	return data, nil
+}
+
+/*
+planChanges renders the CRDs and every chart and dry-runs the resulting changes through EnsureCRD,
+applyTemplate and deleteTemplate, without changing any resource. The resources of the inventory that
+installed components no longer render are planned for deletion like they would be pruned. The changes
+are written per component to the plan ConfigMap in the target namespace.
+*/
+func (r *MultiClusterEngineReconciler) planChanges(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+	plan := newReconcilePlan()
+	r.planCRDs(withPlan(ctx, plan, planCRDsComponent), backplaneConfig)
+
+	previousInventory, err := r.loadInventory(ctx, backplaneConfig)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	inventory := newResourceInventory(previousInventory)
+
+	alwaysCtx := withInventory(withPlan(ctx, plan, alwaysDeployedComponent), inventory, alwaysDeployedComponent)
+	if templates, errs := renderer.RenderCharts(renderer.AlwaysChartsDir, backplaneConfig,
+		r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides); len(errs) > 0 {
+		plan.recordError(alwaysDeployedComponent, errs[0])
+	} else {
+		r.planTemplates(alwaysCtx, backplaneConfig, templates, true)
+	}
+	if !plan.failed(alwaysDeployedComponent) {
+		inventory.markComplete(alwaysDeployedComponent)
+	}
+
+	components, err := componentInstallOrder(registeredComponents())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	installed := map[string]bool{}
+	for _, component := range components {
+		if !component.IsSupported() {
+			continue
+		}
+		if managed := r.externallyManagedNames(backplaneConfig, component); len(managed) > 0 {
+			continue
+		}
+
+		install := false
+		if backplaneConfig.Enabled(component.GetName()) &&
+			len(r.unmetDependencies(ctx, backplaneConfig, component, installed)) == 0 {
+			canInstall, err := component.CanInstall(ctx, r, backplaneConfig)
+			if err != nil {
+				plan.recordError(component.GetName(), err)
+				continue
+			}
+			install = canInstall
+		}
+		if install {
+			installed[component.GetName()] = true
+		}
+		// Components that are not chart based are not planned
+		if component.GetChartDir() == "" {
+			continue
+		}
+		componentCtx := withInventory(withPlan(ctx, plan, component.GetName()), inventory, component.GetName())
+		r.planComponent(componentCtx, backplaneConfig, component, install)
+		// The resources of removed components are planned for deletion with their chart rather than pruned
+		if install && !plan.failed(component.GetName()) {
+			inventory.markComplete(component.GetName())
+		}
+	}
+
+	_, orphans := inventory.reconcile()
+	for _, entry := range orphans {
+		component := inventory.previousComponent(entry)
+		if err := r.pruneResource(withPlan(ctx, plan, component), backplaneConfig, entry); err != nil {
+			plan.recordError(component, fmt.Errorf("%s: %w", entry, err))
+		}
+	}
+
+	data, err := plan.configMapData()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.writePlanConfigMap(ctx, backplaneConfig, data); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	creates, updates, deletes := plan.summary()
+	r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing,
+		metav1.ConditionUnknown, status.PlanModeReason,
+		fmt.Sprintf("Plan written to ConfigMap %s/%s: %d to create, %d to update, %d to delete",
+			backplaneConfig.Spec.TargetNamespace, planConfigMapName, creates, updates, deletes)))
+	return ctrl.Result{}, nil
+}
+
+// planCRDs renders the CRDs and dry-runs applying them.
+func (r *MultiClusterEngineReconciler) planCRDs(ctx context.Context, backplaneConfig *backplanev1.MultiClusterEngine) {
+	pc, _ := planFromContext(ctx)
+
+	crds, errs := renderer.RenderCRDs(crdsDir, backplaneConfig, r.getCRDSkipDirectories(backplaneConfig))
+	if len(errs) > 0 {
+		pc.recordError(errs[0])
+		return
+	}
+	errMessages := []string{}
+	for _, crd := range crds {
+		if err := EnsureCRD(ctx, r.Client, crd); err != nil {
+			errMessages = append(errMessages, err.Error())
+		}
+	}
+	if len(errMessages) > 0 {
+		pc.recordError(fmt.Errorf("%s", strings.Join(errMessages, "; ")))
+	}
+}
+
+// planComponent renders the chart of a component and dry-runs installing or removing it.
+func (r *MultiClusterEngineReconciler) planComponent(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, component Component, install bool) {
+	pc, _ := planFromContext(ctx)
+
+	namespace := backplaneConfig.Spec.TargetNamespace
+	if c, ok := component.(*chartComponent); ok {
+		namespace = c.targetNamespace(backplaneConfig)
+	}
+	templates, errs := r.renderCache.RenderChartWithNamespace(component.GetChartDir(), backplaneConfig,
+		r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides, namespace)
+	if len(errs) > 0 {
+		pc.recordError(errs[0])
+		return
+	}
+
+	if install {
+		if _, err := r.applyComponentDeploymentOverrides(ctx, backplaneConfig, templates, component.GetName()); err != nil {
+			pc.recordError(err)
+			return
+		}
+	}
+	r.planTemplates(ctx, backplaneConfig, templates, install)
+}
+
+// planTemplates dry-runs applying or deleting the templates, skipping NetworkPolicies like the reconcile does.
+func (r *MultiClusterEngineReconciler) planTemplates(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, templates []*unstructured.Unstructured, install bool) {
+	pc, _ := planFromContext(ctx)
+
+	if !install {
+		renderer.SortForUninstall(templates)
+	}
+	errs := []string{}
+	for _, template := range templates {
+		if template.GetKind() == "NetworkPolicy" {
+			continue
+		}
+
+		var err error
+		if install {
+			applyReleaseVersionAnnotation(template)
+			_, err = r.applyTemplate(ctx, backplaneConfig, template)
+		} else {
+			_, err = r.deleteTemplate(ctx, backplaneConfig, template)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", planResourceID(template), err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		pc.recordError(fmt.Errorf("%s", strings.Join(errs, "; ")))
+	}
+}
+
+// writePlanConfigMap creates or replaces the plan ConfigMap in the target namespace.
+func (r *MultiClusterEngineReconciler) writePlanConfigMap(ctx context.Context,
+	backplaneConfig *backplanev1.MultiClusterEngine, data map[string]string) error {
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: planConfigMapName,
+		Namespace: backplaneConfig.Spec.TargetNamespace}, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      planConfigMapName,
+				Namespace: backplaneConfig.Spec.TargetNamespace,
+			},
+			Data: data,
+		}
+		if err := ctrl.SetControllerReference(backplaneConfig, cm, r.Scheme); err != nil {
+			return err
+		}
+		log.Info("Creating plan configmap", "Name", cm.GetName(), "Namespace", cm.GetNamespace())
+		return r.Client.Create(ctx, cm)
+	}
+
+	cm.Data = data
+	return r.Client.Update(ctx, cm)
+}
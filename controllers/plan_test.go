@@ -0,0 +1,203 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_planTemplates(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing",
+			Namespace: "test-ns",
+			Labels:    map[string]string{"backplaneconfig.name": mce.GetName()},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce, existing).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: s, StatusManager: &status.StatusTracker{Client: cl}}
+
+	configMap := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "test-ns"},
+		}}
+	}
+
+	plan := newReconcilePlan()
+	ctx := context.Background()
+	r.planTemplates(withPlan(ctx, plan, "installed"), mce, []*unstructured.Unstructured{configMap("created")}, true)
+	r.planTemplates(withPlan(ctx, plan, "removed"), mce, []*unstructured.Unstructured{configMap("existing")}, false)
+
+	if got := plan.components["installed"].Create; len(got) != 1 || got[0] != "ConfigMap/test-ns/created" {
+		t.Errorf("expected ConfigMap/test-ns/created to be planned for creation, got %v", got)
+	}
+	if got := plan.components["removed"].Delete; len(got) != 1 || got[0] != "ConfigMap/test-ns/existing" {
+		t.Errorf("expected ConfigMap/test-ns/existing to be planned for deletion, got %v", got)
+	}
+
+	// Nothing is changed in plan mode
+	cm := &corev1.ConfigMap{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "created", Namespace: "test-ns"}, cm); err == nil {
+		t.Errorf("expected the planned ConfigMap not to be created")
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "existing", Namespace: "test-ns"}, cm); err != nil {
+		t.Errorf("expected the planned ConfigMap not to be deleted: %v", err)
+	}
+
+	data, err := plan.configMapData()
+	if err != nil {
+		t.Fatalf("configMapData() returned error: %v", err)
+	}
+	if err := r.writePlanConfigMap(ctx, mce, data); err != nil {
+		t.Fatalf("writePlanConfigMap() returned error: %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: planConfigMapName, Namespace: "test-ns"}, cm); err != nil {
+		t.Fatalf("expected the plan ConfigMap to be written: %v", err)
+	}
+	if !strings.Contains(cm.Data["removed"], "ConfigMap/test-ns/existing") {
+		t.Errorf("expected the plan ConfigMap to list the deletion, got %v", cm.Data)
+	}
+
+	if creates, updates, deletes := plan.summary(); creates != 1 || updates != 0 || deletes != 1 {
+		t.Errorf("summary() = %d, %d, %d, want 1, 0, 1", creates, updates, deletes)
+	}
+}
+
+func Test_planResourceChanged(t *testing.T) {
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "cm", "resourceVersion": "1"},
+		"data":       map[string]interface{}{"key": "value"},
+	}}
+
+	planned := existing.DeepCopy()
+	planned.SetResourceVersion("2")
+	if planResourceChanged(existing, planned) {
+		t.Errorf("expected a resourceVersion change to be ignored")
+	}
+
+	planned.Object["data"] = map[string]interface{}{"key": "other"}
+	if !planResourceChanged(existing, planned) {
+		t.Errorf("expected a data change to be detected")
+	}
+}
+
+func Test_planCRDsAndPrune(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	orphan := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "test-ns",
+		Labels: map[string]string{"backplaneconfig.name": mce.GetName()}}}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce, orphan).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: s}
+
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "tests.example.com"},
+	}}
+
+	plan := newReconcilePlan()
+	ctx := context.Background()
+	if err := EnsureCRD(withPlan(ctx, plan, planCRDsComponent), cl, crd); err != nil {
+		t.Fatalf("EnsureCRD() returned error: %v", err)
+	}
+	if err := r.pruneResource(withPlan(ctx, plan, "component"), mce, inventoryConfigMap("orphan")); err != nil {
+		t.Fatalf("pruneResource() returned error: %v", err)
+	}
+
+	if got := plan.components[planCRDsComponent].Create; len(got) != 1 ||
+		got[0] != "CustomResourceDefinition/tests.example.com" {
+		t.Errorf("expected the CRD to be planned for creation, got %v", got)
+	}
+	if got := plan.components["component"].Delete; len(got) != 1 || got[0] != "ConfigMap/test-ns/orphan" {
+		t.Errorf("expected the orphaned ConfigMap to be planned for deletion, got %v", got)
+	}
+
+	// Nothing is changed in plan mode
+	existing := crd.DeepCopy()
+	if err := cl.Get(ctx, types.NamespacedName{Name: crd.GetName()}, existing); err == nil {
+		t.Errorf("expected the planned CRD not to be created")
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "orphan", Namespace: "test-ns"}, &corev1.ConfigMap{}); err != nil {
+		t.Errorf("expected the orphaned ConfigMap not to be pruned: %v", err)
+	}
+}
+
+func Test_planComponentDeploymentOverrides(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{
+		Spec: backplanev1.MultiClusterEngineSpec{
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{{
+					Name:    backplanev1.Discovery,
+					Enabled: true,
+					ConfigOverrides: backplanev1.ConfigOverride{
+						Deployments: []backplanev1.DeploymentConfig{{
+							Name: "discovery-operator",
+							Containers: []backplanev1.ContainerConfig{{
+								Name:  "discovery-operator",
+								Image: "quay.io/test/discovery:test",
+							}},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "discovery-operator"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "discovery-operator", "image": "discovery"},
+					},
+				},
+			},
+		},
+	}}
+
+	r := &MultiClusterEngineReconciler{StatusManager: &status.StatusTracker{}}
+	ctx := withPlan(context.Background(), newReconcilePlan(), backplanev1.Discovery)
+	if _, err := r.applyComponentDeploymentOverrides(ctx, mce, []*unstructured.Unstructured{deployment},
+		backplanev1.Discovery); err != nil {
+		t.Fatalf("applyComponentDeploymentOverrides() returned error: %v", err)
+	}
+
+	containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	if got := containers[0].(map[string]interface{})["image"]; got != "quay.io/test/discovery:test" {
+		t.Errorf("expected the image override to be applied to the planned template, got %v", got)
+	}
+	reported := r.StatusManager.ReportStatus(*mce)
+	if len(reported.Footprint) != 0 || len(reported.ContainerOverrides) != 0 {
+		t.Errorf("expected plan mode not to record the footprint or container overrides, got %v and %v",
+			reported.Footprint, reported.ContainerOverrides)
+	}
+}
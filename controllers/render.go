@@ -0,0 +1,117 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/foundation"
+	"github.com/stolostron/backplane-operator/pkg/hive"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/toggle"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+/*
+RenderManifests renders the resources the operator deploys for the MultiClusterEngine without
+connecting to a cluster: the CRDs, the charts always deployed, the chart of every enabled component
+with its deployment overrides, the ClusterManager and the HiveConfig. A MultiClusterEngine in hosted
+mode renders the hosted charts instead of the components. The defaults the operator sets on the
+MultiClusterEngine are applied first, with the MCE console defaulted from ocpVersion when it is set.
+Prerequisites checked against the cluster, such as the presence of the console or of the add-on CRDs,
+are assumed to be met. The resources are returned in the order they are applied in.
+*/
+func RenderManifests(mce *backplanev1.MultiClusterEngine, images map[string]string,
+	templateOverrides map[string]string, ocpVersion string) ([]*unstructured.Unstructured, error) {
+	mce = mce.DeepCopy()
+	setStaticDefaults(mce)
+	if utils.DeployOnOCP() && ocpVersion != "" {
+		if _, err := setConsoleMCEDefault(mce, ocpVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &MultiClusterEngineReconciler{
+		CacheSpec: CacheSpec{ImageOverrides: images, TemplateOverrides: templateOverrides},
+	}
+	manifests := []*unstructured.Unstructured{}
+
+	crds, errs := renderer.RenderCRDs(crdsDir, mce, r.getCRDSkipDirectories(mce))
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to render CRDs: %v", errs[0])
+	}
+	manifests = append(manifests, crds...)
+
+	templates, errs := renderer.RenderCharts(renderer.AlwaysChartsDir, mce, images, templateOverrides)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to render charts in %s: %v", renderer.AlwaysChartsDir, errs[0])
+	}
+	manifests = append(manifests, applyRenderedAnnotations(templates, "")...)
+
+	if backplanev1.IsInHostedMode(mce) {
+		for _, chartDir := range []string{toggle.HostedImportChartDir, toggle.HostingImportChartDir} {
+			templates, errs := renderer.RenderChart(chartDir, mce, images, templateOverrides)
+			if len(errs) > 0 {
+				return nil, fmt.Errorf("failed to render chart %s: %v", chartDir, errs[0])
+			}
+			manifests = append(manifests, applyRenderedAnnotations(templates, "")...)
+		}
+		return renderer.SortForInstall(manifests), nil
+	}
+
+	for _, component := range registeredComponents() {
+		if !component.IsSupported() || !mce.Enabled(component.GetName()) ||
+			len(r.externallyManagedNames(mce, component)) > 0 {
+			continue
+		}
+		components, err := r.renderComponent(mce, component)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, components...)
+	}
+	return renderer.SortForInstall(manifests), nil
+}
+
+// renderComponent renders the chart of the component, with the resources the operator derives for it.
+func (r *MultiClusterEngineReconciler) renderComponent(mce *backplanev1.MultiClusterEngine,
+	component Component) ([]*unstructured.Unstructured, error) {
+	templates := []*unstructured.Unstructured{}
+	if chartDir := component.GetChartDir(); chartDir != "" {
+		namespace := mce.Spec.TargetNamespace
+		if c, ok := component.(*chartComponent); ok {
+			namespace = c.targetNamespace(mce)
+		}
+		rendered, errs := renderer.RenderChartWithNamespace(chartDir, mce, r.CacheSpec.ImageOverrides,
+			r.CacheSpec.TemplateOverrides, namespace)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("failed to render chart %s: %v", chartDir, errs[0])
+		}
+		if _, err := r.applyComponentDeploymentOverrides(context.TODO(), mce, rendered, component.GetName()); err != nil {
+			return nil, err
+		}
+		templates = append(templates, rendered...)
+	}
+
+	switch component.GetName() {
+	case backplanev1.ClusterManager:
+		templates = append(templates, foundation.ClusterManager(mce, r.CacheSpec.ImageOverrides))
+	case backplanev1.Hive:
+		templates = append(templates, hive.HiveConfig(mce))
+	}
+	return applyRenderedAnnotations(templates, component.GetName()), nil
+}
+
+// applyRenderedAnnotations sets the release version annotation and the component label the operator applies.
+func applyRenderedAnnotations(templates []*unstructured.Unstructured, component string) []*unstructured.Unstructured {
+	for _, template := range templates {
+		applyReleaseVersionAnnotation(template)
+		if component != "" {
+			utils.AddComponentLabel(template, component)
+		}
+	}
+	return templates
+}
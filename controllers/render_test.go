@@ -0,0 +1,67 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_RenderManifests(t *testing.T) {
+	useTemplateFS(t, os.DirFS(".."))
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+
+	images := map[string]string{}
+	for _, image := range utils.GetTestImages() {
+		images[strings.ToLower(image)] = "quay.io/test/test:test"
+	}
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{{Name: backplanev1.Discovery, Enabled: false}},
+			},
+		},
+	}
+
+	manifests, err := RenderManifests(mce, images, map[string]string{}, "4.16.0")
+	if err != nil {
+		t.Fatalf("RenderManifests() returned error: %v", err)
+	}
+	if mce.Spec.TargetNamespace != "" {
+		t.Errorf("expected the MultiClusterEngine not to be modified")
+	}
+
+	found := map[string]bool{}
+	first, last := map[string]int{}, map[string]int{}
+	for i, m := range manifests {
+		found[m.GetKind()+"/"+m.GetName()] = true
+		if _, ok := first[m.GetKind()]; !ok {
+			first[m.GetKind()] = i
+		}
+		last[m.GetKind()] = i
+		if m.GetKind() == "Deployment" && m.GetNamespace() != backplanev1.DefaultTargetNamespace &&
+			m.GetLabels()[utils.ComponentLabel] != backplanev1.AssistedService {
+			t.Errorf("expected deployment %s in the default target namespace, got %s", m.GetName(), m.GetNamespace())
+		}
+	}
+	for _, want := range []string{"ClusterManager/cluster-manager", "HiveConfig/hive", "Deployment/cluster-manager",
+		"Deployment/console-mce-console"} {
+		if !found[want] {
+			t.Errorf("expected %s to be rendered", want)
+		}
+	}
+	if last["CustomResourceDefinition"] > first["Deployment"] || last["Deployment"] > first["ClusterManager"] {
+		t.Errorf("expected the manifests in install order, got CRDs at %d-%d, Deployments at %d-%d and ClusterManager at %d",
+			first["CustomResourceDefinition"], last["CustomResourceDefinition"], first["Deployment"],
+			last["Deployment"], first["ClusterManager"])
+	}
+	if found["Deployment/discovery-operator"] {
+		t.Errorf("expected the disabled discovery component not to be rendered")
+	}
+}
@@ -21,7 +21,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -45,6 +47,7 @@ import (
 	. "github.com/onsi/gomega"
 	v1 "github.com/stolostron/backplane-operator/api/v1"
 	"github.com/stolostron/backplane-operator/pkg/overrides"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/status"
 	"github.com/stolostron/backplane-operator/pkg/utils"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -90,6 +93,25 @@ func addToSchemeIgnoringDuplicate(addToScheme func(*runtime.Scheme) error, s *ru
 	return addToScheme(s)
 }
 
+// unitTestTemplates serves the templates of the repository at its root, with the CRDs used by the unit
+// tests in place of the CRDs of the components.
+type unitTestTemplates string
+
+func (root unitTestTemplates) Open(name string) (fs.File, error) {
+	if rest, ok := strings.CutPrefix(name, crdsDir); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+		return os.DirFS(path.Join(string(root), "test/unit-test-crds")).Open(path.Join(".", rest))
+	}
+	return os.DirFS(string(root)).Open(name)
+}
+
+// useTemplateFS reads the templates from fsys for the duration of the test.
+func useTemplateFS(t *testing.T, fsys fs.FS) {
+	t.Helper()
+	saved := renderer.TemplateFS()
+	renderer.SetTemplateFS(fsys)
+	t.Cleanup(func() { renderer.SetTemplateFS(saved) })
+}
+
 var _ = BeforeSuite(func() {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 
@@ -165,8 +187,7 @@ var _ = BeforeSuite(func() {
 	err = os.Setenv("POD_NAMESPACE", "default")
 	Expect(err).NotTo(HaveOccurred())
 
-	err = os.Setenv("DIRECTORY_OVERRIDE", "../")
-	Expect(err).NotTo(HaveOccurred())
+	renderer.SetTemplateFS(unitTestTemplates(".."))
 
 	err = os.Setenv("UNIT_TEST", "true")
 	Expect(err).NotTo(HaveOccurred())
@@ -259,8 +280,6 @@ var _ = AfterSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	err = os.Unsetenv("UNIT_TEST")
 	Expect(err).NotTo(HaveOccurred())
-	err = os.Unsetenv("DIRECTORY_OVERRIDE")
-	Expect(err).NotTo(HaveOccurred())
 
 	cancel()
 	By("tearing down the test environment")
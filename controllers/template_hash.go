@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+/*
+templateHash returns the hash of the rendered content of the template, ignoring a hash annotation
+already set on it. Image, template and configuration overrides are applied to the template while it
+is rendered, so the hash changes whenever the effective overrides change.
+*/
+func templateHash(template *unstructured.Unstructured) string {
+	obj := template.DeepCopy()
+	annotations := obj.GetAnnotations()
+	delete(annotations, utils.AnnotationTemplateHash)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+
+	// Map keys are sorted when marshalled, so equal templates produce equal hashes
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// setTemplateHash annotates the template with the hash of its rendered content.
+func setTemplateHash(template *unstructured.Unstructured) {
+	hash := templateHash(template)
+	if hash == "" {
+		return
+	}
+	annotations := template.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[utils.AnnotationTemplateHash] = hash
+	template.SetAnnotations(annotations)
+}
+
+// templateUnchanged reports whether the live resource was last applied from the same rendered content as the template.
+func templateUnchanged(template, live *unstructured.Unstructured) bool {
+	hash := template.GetAnnotations()[utils.AnnotationTemplateHash]
+	return hash != "" && live.GetAnnotations()[utils.AnnotationTemplateHash] == hash
+}
@@ -0,0 +1,146 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"github.com/stolostron/backplane-operator/pkg/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func hashedConfigMap(value string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "hashed",
+			"namespace": "test-ns",
+			"labels":    map[string]interface{}{"backplaneconfig.name": "multiclusterengine"},
+		},
+		"data": map[string]interface{}{"key": value},
+	}}
+}
+
+func Test_templateHash(t *testing.T) {
+	template := hashedConfigMap("value")
+	hash := templateHash(template)
+	if hash == "" {
+		t.Fatalf("expected a hash of the template")
+	}
+
+	setTemplateHash(template)
+	if template.GetAnnotations()[utils.AnnotationTemplateHash] != hash {
+		t.Errorf("expected the template to be annotated with its hash %s, got %v", hash, template.GetAnnotations())
+	}
+	if templateHash(template) != hash {
+		t.Errorf("expected the hash annotation to be ignored when hashing")
+	}
+	if templateHash(hashedConfigMap("changed")) == hash {
+		t.Errorf("expected a changed template to have a different hash")
+	}
+
+	live := template.DeepCopy()
+	if !templateUnchanged(template, live) {
+		t.Errorf("expected a live resource with the same hash to be unchanged")
+	}
+	if templateUnchanged(hashedConfigMap("value"), live) {
+		t.Errorf("expected a template without a hash to be applied")
+	}
+}
+
+func Test_applyTemplate_skipsUnchanged(t *testing.T) {
+	s := scheme.Scheme
+	backplanev1.AddToScheme(s)
+	t.Setenv("OPERATOR_VERSION", version.Version)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	patches := 0
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(mce).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+			opts ...client.PatchOption) error {
+			patches++
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: s, StatusManager: &status.StatusTracker{Client: cl}}
+	r.StatusManager.Reset("")
+
+	apply := func(value string) {
+		t.Helper()
+		template := hashedConfigMap(value)
+		applyReleaseVersionAnnotation(template)
+		if _, err := r.applyTemplate(context.Background(), mce, template); err != nil {
+			t.Fatalf("applyTemplate() returned error: %v", err)
+		}
+	}
+
+	apply("value")
+	apply("value")
+	if patches != 0 {
+		t.Errorf("expected an unchanged template not to be patched, got %d patches", patches)
+	}
+
+	apply("changed")
+	if patches != 1 {
+		t.Errorf("expected a changed template to be patched once, got %d patches", patches)
+	}
+
+	// A live resource edited away from its template is patched even though its hash matches
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(hashedConfigMap("").GroupVersionKind())
+	if err := cl.Get(context.Background(), client.ObjectKey{Name: "hashed", Namespace: "test-ns"}, live); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if err := unstructured.SetNestedField(live.Object, "edited", "data", "key"); err != nil {
+		t.Fatalf("failed to edit ConfigMap: %v", err)
+	}
+	if err := cl.Update(context.Background(), live); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	apply("changed")
+	if patches != 2 {
+		t.Errorf("expected a drifted resource to be patched, got %d patches", patches)
+	}
+}
+
+func Test_EnsureCRD_skipsUnchanged(t *testing.T) {
+	crd := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata":   map[string]interface{}{"name": "tests.example.com"},
+			"spec":       map[string]interface{}{"group": "example.com"},
+		}}
+	}
+
+	updates := 0
+	cl := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			updates++
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+
+	for i := 0; i < 2; i++ {
+		if err := EnsureCRD(context.TODO(), cl, crd()); err != nil {
+			t.Fatalf("EnsureCRD() returned error: %v", err)
+		}
+	}
+	if updates != 0 {
+		t.Errorf("expected an unchanged CRD not to be updated, got %d updates", updates)
+	}
+}
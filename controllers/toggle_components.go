@@ -55,18 +55,18 @@ func (r *MultiClusterEngineReconciler) ensureConsoleMCE(ctx context.Context, mce
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.ConsoleMCE)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides,
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides,
 		r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ConsoleMCE); err != nil {
+	if result, err := r.applyComponentDeploymentOverrides(ctx, mce, templates, backplanev1.ConsoleMCE); err != nil {
 		return result, err
 	}
 
@@ -130,16 +130,17 @@ func (r *MultiClusterEngineReconciler) ensureNoConsoleMCE(ctx context.Context, m
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.ConsoleMCE)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
-	// Deletes all templates
+	// Deletes all templates, in the reverse of the install order
+	renderer.SortForUninstall(templates)
 	for _, template := range templates {
 		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
 		if template.GetKind() == "NetworkPolicy" {
@@ -175,17 +176,17 @@ func (r *MultiClusterEngineReconciler) ensureManagedServiceAccount(ctx context.C
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.ManagedServiceAccount)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ManagedServiceAccount); err != nil {
+	if result, err := r.applyComponentDeploymentOverrides(ctx, mce, templates, backplanev1.ManagedServiceAccount); err != nil {
 		return result, err
 	}
 
@@ -225,18 +226,19 @@ func (r *MultiClusterEngineReconciler) ensureNoManagedServiceAccount(ctx context
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.ManagedServiceAccount)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	r.StatusManager.AddComponent(toggle.DisabledStatus(types.NamespacedName{Name: "managedservice",
 		Namespace: mce.Spec.TargetNamespace}, []*unstructured.Unstructured{}))
 
-	// Deletes all templates
+	// Deletes all templates, in the reverse of the install order
+	renderer.SortForUninstall(templates)
 	for _, template := range templates {
 		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
 		if template.GetKind() == "NetworkPolicy" {
@@ -270,13 +272,13 @@ func (r *MultiClusterEngineReconciler) ensureFleetNavigation(ctx context.Context
 	}
 
 	chartPath := r.fetchChartOrCRDPath(backplanev1.FleetNavigation)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	missingCRDErrorOccured := false
@@ -310,17 +312,18 @@ func (r *MultiClusterEngineReconciler) ensureNoFleetNavigation(ctx context.Conte
 	}
 
 	chartPath := r.fetchChartOrCRDPath(backplanev1.FleetNavigation)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	r.StatusManager.AddComponent(toggle.DisabledStatus(types.NamespacedName{Name: backplanev1.FleetNavigation,
 		Namespace: mce.Spec.TargetNamespace}, []*unstructured.Unstructured{}))
 
+	renderer.SortForUninstall(templates)
 	for _, template := range templates {
 		if template.GetKind() == foundation.ClusterManagementAddonKind && !foundation.CanInstallAddons(ctx, r.Client) {
 			continue
@@ -349,915 +352,89 @@ func (r *MultiClusterEngineReconciler) addPluginToConsoleResource(ctx context.Co
 	if console.Spec.Plugins == nil {
 		console.Spec.Plugins = []string{}
 	}
-
-	// Add mce to the plugins list if it is not already there
-	if !utils.Contains(console.Spec.Plugins, "mce") {
-		log.Info("Ready to add plugin")
-		console.Spec.Plugins = append(console.Spec.Plugins, "mce")
-		err = r.Client.Update(ctx, console)
-		if err != nil {
-			log.Info("Failed to add mce consoleplugin to console")
-			return ctrl.Result{}, err
-		} else {
-			log.Info("Added mce consoleplugin to console")
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-// removePluginFromConsoleResource ...
-func (r *MultiClusterEngineReconciler) removePluginFromConsoleResource(ctx context.Context) (ctrl.Result, error) {
-	if val, ok := os.LookupEnv("UNIT_TEST"); ok && val == "true" {
-		return ctrl.Result{}, nil
-	}
-
-	console := &operatorv1.Console{}
-	// If trying to check this resource from the CLI run - `oc get consoles.operator.openshift.io cluster`.
-	// The default `console` is not the correct resource
-	err := r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, console)
-	if err != nil {
-		log.Info("Failed to find console: cluster")
-		return ctrl.Result{}, err
-	}
-
-	// If No plugins, it is already removed
-	if console.Spec.Plugins == nil {
-		return ctrl.Result{}, nil
-	}
-
-	// Remove mce to the plugins list if it is not already there
-	if utils.Contains(console.Spec.Plugins, "mce") {
-		console.Spec.Plugins = utils.Remove(console.Spec.Plugins, "mce")
-		err = r.Client.Update(ctx, console)
-		if err != nil {
-			log.Info("Failed to remove mce consoleplugin to console")
-			return ctrl.Result{}, err
-		} else {
-			log.Info("Removed mce consoleplugin to console")
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureDiscovery(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "discovery-operator", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.Discovery); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.Discovery)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.Discovery); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoDiscovery(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-	namespacedName := types.NamespacedName{Name: "discovery-operator", Namespace: mce.Spec.TargetNamespace}
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.Discovery); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.Discovery)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureClusterAPI(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "capi-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ClusterAPI); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPI)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterAPI); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoClusterAPI(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-	namespacedName := types.NamespacedName{Name: "capi-controller-manager", Namespace: mce.Spec.TargetNamespace}
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ClusterAPI); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPI)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureClusterAPIProviderAWS(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "capa-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ClusterAPIProviderAWS); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPIProviderAWS)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterAPIProviderAWS); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoClusterAPIProviderAWS(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-	namespacedName := types.NamespacedName{Name: "capa-controller-manager", Namespace: mce.Spec.TargetNamespace}
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ClusterAPIProviderAWS); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPIProviderAWS)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureClusterAPIProviderAzure(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "azureserviceoperator-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	namespacedName = types.NamespacedName{Name: "capz-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ClusterAPIProviderAzurePreview); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPIProviderAzurePreview)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterAPIProviderAzurePreview); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoClusterAPIProviderAzure(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "azureserviceoperator-controller-manager",
-		Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	namespacedName = types.NamespacedName{Name: "capz-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ClusterAPIProviderAzurePreview); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPIProviderAzurePreview)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureClusterAPIProviderMetal(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "mce-capm3-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ClusterAPIProviderMetal); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPIProviderMetal)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterAPIProviderMetal); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoClusterAPIProviderMetal(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-	namespacedName := types.NamespacedName{Name: "mce-capm3-controller-manager", Namespace: mce.Spec.TargetNamespace}
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ClusterAPIProviderMetal); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPIProviderMetal)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureClusterAPIProviderOA(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "capoa-bootstrap-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-	namespacedName = types.NamespacedName{Name: "capoa-controlplane-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ClusterAPIProviderOA); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPIProviderOA)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterAPIProviderOA); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoClusterAPIProviderOA(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-	namespacedName := types.NamespacedName{Name: "capoa-bootstrap-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	namespacedName = types.NamespacedName{Name: "capoa-controlplane-controller-manager", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ClusterAPIProviderOA); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterAPIProviderOA)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureHive(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "hive-operator", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.Hive); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.Hive)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.Hive); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	hiveTemplate := hive.HiveConfig(mce)
-	return r.ensureUnstructuredResource(ctx, mce, hiveTemplate)
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoHive(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "hive-operator", Namespace: mce.Spec.TargetNamespace}
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.Hive); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.Hive)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Delete hivconfig
-	hiveConfig := hive.HiveConfig(mce)
-	err := r.Client.Get(ctx, types.NamespacedName{Name: "hive"}, hiveConfig)
-	if err == nil { // If resource exists, delete
-		err := r.Client.Delete(ctx, hiveConfig)
-		if err != nil && !apierrors.IsNotFound(err) {
-			return ctrl.Result{}, err
-		}
-
-	} else if !apierrors.IsNotFound(err) {
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureAssistedService(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-
-	targetNamespace := mce.Spec.TargetNamespace
-	if mce.Spec.Overrides != nil && mce.Spec.Overrides.InfrastructureCustomNamespace != "" {
-		targetNamespace = mce.Spec.Overrides.InfrastructureCustomNamespace
-	}
-
-	namespacedName := types.NamespacedName{Name: "infrastructure-operator", Namespace: targetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.AssistedService); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.AssistedService)
-	templates, errs := renderer.RenderChartWithNamespace(chartPath, mce,
-		r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides, targetNamespace)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.AssistedService); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoAssistedService(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-
-	targetNamespace := mce.Spec.TargetNamespace
-	if mce.Spec.Overrides != nil && mce.Spec.Overrides.InfrastructureCustomNamespace != "" {
-		targetNamespace = mce.Spec.Overrides.InfrastructureCustomNamespace
-	}
-	namespacedName := types.NamespacedName{Name: "infrastructure-operator", Namespace: targetNamespace}
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.AssistedService); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.AssistedService)
-	templates, errs := renderer.RenderChartWithNamespace(chartPath, mce, r.CacheSpec.ImageOverrides,
-		r.CacheSpec.TemplateOverrides, targetNamespace)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureServerFoundation(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "ocm-controller", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-	if utils.DeployOnOCP() {
-		namespacedName = types.NamespacedName{Name: "ocm-proxyserver", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-		r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-	}
-
-	namespacedName = types.NamespacedName{Name: "ocm-webhook", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ServerFoundation); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ServerFoundation)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ServerFoundation); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
+
+	// Add mce to the plugins list if it is not already there
+	if !utils.Contains(console.Spec.Plugins, "mce") {
+		log.Info("Ready to add plugin")
+		console.Spec.Plugins = append(console.Spec.Plugins, "mce")
+		err = r.Client.Update(ctx, console)
 		if err != nil {
-			return result, err
+			log.Info("Failed to add mce consoleplugin to console")
+			return ctrl.Result{}, err
+		} else {
+			log.Info("Added mce consoleplugin to console")
 		}
 	}
 
 	return ctrl.Result{}, nil
 }
 
-func (r *MultiClusterEngineReconciler) ensureNoServerFoundation(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ServerFoundation); (result != ctrl.Result{}) || err != nil {
-		return result, err
+// removePluginFromConsoleResource ...
+func (r *MultiClusterEngineReconciler) removePluginFromConsoleResource(ctx context.Context) (ctrl.Result, error) {
+	if val, ok := os.LookupEnv("UNIT_TEST"); ok && val == "true" {
+		return ctrl.Result{}, nil
 	}
 
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ServerFoundation)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	console := &operatorv1.Console{}
+	// If trying to check this resource from the CLI run - `oc get consoles.operator.openshift.io cluster`.
+	// The default `console` is not the correct resource
+	err := r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, console)
+	if err != nil {
+		log.Info("Failed to find console: cluster")
+		return ctrl.Result{}, err
 	}
 
-	namespacedName := types.NamespacedName{Name: "ocm-controller", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	if utils.DeployOnOCP() {
-		namespacedName = types.NamespacedName{Name: "ocm-proxyserver", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-		r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
+	// If No plugins, it is already removed
+	if console.Spec.Plugins == nil {
+		return ctrl.Result{}, nil
 	}
 
-	namespacedName = types.NamespacedName{Name: "ocm-webhook", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
+	// Remove mce to the plugins list if it is not already there
+	if utils.Contains(console.Spec.Plugins, "mce") {
+		console.Spec.Plugins = utils.Remove(console.Spec.Plugins, "mce")
+		err = r.Client.Update(ctx, console)
 		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
+			log.Info("Failed to remove mce consoleplugin to console")
+			return ctrl.Result{}, err
+		} else {
+			log.Info("Removed mce consoleplugin to console")
 		}
 	}
+
 	return ctrl.Result{}, nil
 }
 
-func (r *MultiClusterEngineReconciler) ensureImageBasedInstallOperator(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+/*
+ensureComponent installs a chart based component: its deployments are tracked in the
+status, its InternalEngineComponent is created and every rendered template (except
+NetworkPolicies) is applied.
+*/
+func (r *MultiClusterEngineReconciler) ensureComponent(ctx context.Context, mce *backplanev1.MultiClusterEngine,
+	c *chartComponent) (ctrl.Result, error) {
 
-	namespacedName := types.NamespacedName{Name: "image-based-install-operator", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
+	for _, namespacedName := range c.deploymentNames(mce) {
+		r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
+		r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
+	}
 
 	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ImageBasedInstallOperator); err != nil {
+	if result, err := r.ensureInternalEngineComponent(ctx, mce, c.GetName()); err != nil {
 		return result, err
 	}
 
 	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ImageBasedInstallOperator)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChartWithNamespace(c.GetChartDir(), mce, r.CacheSpec.ImageOverrides,
+		r.CacheSpec.TemplateOverrides, c.targetNamespace(mce))
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates,
-		backplanev1.ImageBasedInstallOperator); err != nil {
+	if result, err := r.applyComponentDeploymentOverrides(ctx, mce, templates, c.GetName()); err != nil {
 		return result, err
 	}
 
@@ -1278,33 +455,38 @@ func (r *MultiClusterEngineReconciler) ensureImageBasedInstallOperator(ctx conte
 	return ctrl.Result{}, nil
 }
 
-func (r *MultiClusterEngineReconciler) ensureNoImageBasedInstallOperator(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-
-	targetNamespace := mce.Spec.TargetNamespace
-	namespacedName := types.NamespacedName{Name: "image-based-install-operator", Namespace: targetNamespace}
+/*
+ensureNoComponent removes a chart based component: its InternalEngineComponent is deleted,
+its deployments are reported as disabled and every rendered template (except
+NetworkPolicies) is deleted.
+*/
+func (r *MultiClusterEngineReconciler) ensureNoComponent(ctx context.Context, mce *backplanev1.MultiClusterEngine,
+	c *chartComponent) (ctrl.Result, error) {
 
 	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
 	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ImageBasedInstallOperator); (result != ctrl.Result{}) || err != nil {
+		c.GetName()); (result != ctrl.Result{}) || err != nil {
 		return result, err
 	}
 
 	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ImageBasedInstallOperator)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChartWithNamespace(c.GetChartDir(), mce, r.CacheSpec.ImageOverrides,
+		r.CacheSpec.TemplateOverrides, c.targetNamespace(mce))
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
+	for _, namespacedName := range c.deploymentNames(mce) {
+		r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
+		r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
+	}
 
-	// Deletes all templates
+	// Deletes all templates, in the reverse of the install order
+	renderer.SortForUninstall(templates)
 	for _, template := range templates {
 		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
 		if template.GetKind() == "NetworkPolicy" {
@@ -1320,46 +502,31 @@ func (r *MultiClusterEngineReconciler) ensureNoImageBasedInstallOperator(ctx con
 	return ctrl.Result{}, nil
 }
 
-func (r *MultiClusterEngineReconciler) ensureClusterLifecycle(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+func (r *MultiClusterEngineReconciler) ensureHive(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
+	ctrl.Result, error) {
 
-	if utils.DeployOnOCP() {
-		namespacedName := types.NamespacedName{Name: "cluster-curator-controller", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-		r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-		namespacedName = types.NamespacedName{Name: "clusterclaims-controller", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-		r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-		namespacedName = types.NamespacedName{Name: "provider-credential-controller", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-		r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-		namespacedName = types.NamespacedName{Name: "cluster-image-set-controller", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-		r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-	}
-	namespacedName := types.NamespacedName{Name: "clusterlifecycle-state-metrics-v2", Namespace: mce.Spec.TargetNamespace}
+	namespacedName := types.NamespacedName{Name: "hive-operator", Namespace: mce.Spec.TargetNamespace}
 	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
 	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
 
 	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ClusterLifecycle); err != nil {
+	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.Hive); err != nil {
 		return result, err
 	}
 
 	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterLifecycle)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides,
-		r.CacheSpec.TemplateOverrides)
+	chartPath := r.fetchChartOrCRDPath(backplanev1.Hive)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterLifecycle); err != nil {
+	if result, err := r.applyComponentDeploymentOverrides(ctx, mce, templates, backplanev1.Hive); err != nil {
 		return result, err
 	}
 
@@ -1377,45 +544,50 @@ func (r *MultiClusterEngineReconciler) ensureClusterLifecycle(ctx context.Contex
 		}
 	}
 
-	return ctrl.Result{}, nil
+	hiveTemplate := hive.HiveConfig(mce)
+	return r.ensureUnstructuredResource(ctx, mce, hiveTemplate)
 }
 
-func (r *MultiClusterEngineReconciler) ensureNoClusterLifecycle(ctx context.Context,
-	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+func (r *MultiClusterEngineReconciler) ensureNoHive(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
+	ctrl.Result, error) {
+
+	namespacedName := types.NamespacedName{Name: "hive-operator", Namespace: mce.Spec.TargetNamespace}
 
 	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
 	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ClusterLifecycle); (result != ctrl.Result{}) || err != nil {
+		backplanev1.Hive); (result != ctrl.Result{}) || err != nil {
 		return result, err
 	}
 
 	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterLifecycle)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	chartPath := r.fetchChartOrCRDPath(backplanev1.Hive)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
-	if utils.DeployOnOCP() {
-		namespacedName := types.NamespacedName{Name: "cluster-curator-controller", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-		r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-		namespacedName = types.NamespacedName{Name: "clusterclaims-controller", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-		r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-		namespacedName = types.NamespacedName{Name: "provider-credential-controller", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-		r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-		namespacedName = types.NamespacedName{Name: "cluster-image-set-controller", Namespace: mce.Spec.TargetNamespace}
-		r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-		r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
+	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
+	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
+
+	// Delete hivconfig
+	hiveConfig := hive.HiveConfig(mce)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: "hive"}, hiveConfig)
+	if err == nil { // If resource exists, delete
+		err := r.Client.Delete(ctx, hiveConfig)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+	} else if !apierrors.IsNotFound(err) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
 	}
 
-	// Deletes all templates
+	// Deletes all templates, in the reverse of the install order
+	renderer.SortForUninstall(templates)
 	for _, template := range templates {
 		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
 		if template.GetKind() == "NetworkPolicy" {
@@ -1448,17 +620,17 @@ func (r *MultiClusterEngineReconciler) ensureClusterManager(ctx context.Context,
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterManager)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterManager); err != nil {
+	if result, err := r.applyComponentDeploymentOverrides(ctx, mce, templates, backplanev1.ClusterManager); err != nil {
 		return result, err
 	}
 
@@ -1495,6 +667,12 @@ func (r *MultiClusterEngineReconciler) ensureClusterManager(ctx context.Context,
 		return ctrl.Result{}, err
 	}
 
+	// The add-on components that depend on the cluster manager are installed once it serves the add-on API
+	if _, planning := planFromContext(ctx); !planning && !foundation.CanInstallAddons(ctx, r.Client) {
+		log.Info("Waiting for the cluster manager to install the ClusterManagementAddOn CRD")
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -1510,13 +688,13 @@ func (r *MultiClusterEngineReconciler) ensureNoClusterManager(ctx context.Contex
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterManager)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
@@ -1554,93 +732,8 @@ func (r *MultiClusterEngineReconciler) ensureNoClusterManager(ctx context.Contex
 		return ctrl.Result{}, err
 	}
 
-	// Deletes all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		result, err := r.deleteTemplate(ctx, mce, template)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to delete template: %s", template.GetName()))
-			return result, err
-		}
-	}
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureClusterPermission(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "cluster-permission", Namespace: mce.Spec.TargetNamespace}
-	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
-
-	// Ensure that the InternalHubComponent CR instance is created for component in MCE.
-	if result, err := r.ensureInternalEngineComponent(ctx, mce, backplanev1.ClusterPermission); err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterPermission)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterPermission); err != nil {
-		return result, err
-	}
-
-	// Applies all templates
-	for _, template := range templates {
-		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
-		if template.GetKind() == "NetworkPolicy" {
-			continue
-		}
-
-		applyReleaseVersionAnnotation(template)
-		result, err := r.applyTemplate(ctx, mce, template)
-		if err != nil {
-			return result, err
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *MultiClusterEngineReconciler) ensureNoClusterPermission(ctx context.Context, mce *backplanev1.MultiClusterEngine) (
-	ctrl.Result, error) {
-
-	namespacedName := types.NamespacedName{Name: "cluster-permission", Namespace: mce.Spec.TargetNamespace}
-
-	// Ensure that the InternalHubComponent CR instance is deleted for component in MCE.
-	if result, err := r.ensureNoInternalEngineComponent(ctx, mce,
-		backplanev1.ClusterPermission); (result != ctrl.Result{}) || err != nil {
-		return result, err
-	}
-
-	// Renders all templates from charts
-	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterPermission)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
-
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.Info(err.Error())
-		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
-	}
-
-	r.StatusManager.RemoveComponent(toggle.EnabledStatus(namespacedName))
-	r.StatusManager.AddComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
-
-	// Deletes all templates
+	// Deletes all templates, in the reverse of the install order
+	renderer.SortForUninstall(templates)
 	for _, template := range templates {
 		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
 		if template.GetKind() == "NetworkPolicy" {
@@ -1671,17 +764,17 @@ func (r *MultiClusterEngineReconciler) ensureHyperShift(ctx context.Context, mce
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.HyperShift)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.HyperShift); err != nil {
+	if result, err := r.applyComponentDeploymentOverrides(ctx, mce, templates, backplanev1.HyperShift); err != nil {
 		return result, err
 	}
 
@@ -1761,16 +854,17 @@ func (r *MultiClusterEngineReconciler) ensureNoHyperShift(ctx context.Context,
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.HyperShift)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
-	// Deletes all templates
+	// Deletes all templates, in the reverse of the install order
+	renderer.SortForUninstall(templates)
 	for _, template := range templates {
 		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
 		if template.GetKind() == "NetworkPolicy" {
@@ -1786,41 +880,13 @@ func (r *MultiClusterEngineReconciler) ensureNoHyperShift(ctx context.Context,
 	return ctrl.Result{}, nil
 }
 
-func (r *MultiClusterEngineReconciler) reconcileHypershiftLocalHosting(ctx context.Context, mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+func (r *MultiClusterEngineReconciler) ensureHypershiftLocalHosting(ctx context.Context,
+	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
 	addon, err := renderer.RenderHypershiftAddon(mce)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if !mce.Enabled(backplanev1.HypershiftLocalHosting) {
-		r.StatusManager.AddComponent(status.NewDisabledStatus(
-			types.NamespacedName{Name: addon.GetName(), Namespace: addon.GetNamespace()},
-			"Component is disabled",
-			[]*unstructured.Unstructured{addon},
-		))
-		return r.removeHypershiftLocalHosting(ctx, mce)
-	}
-
-	if !mce.Enabled(backplanev1.HyperShift) {
-		// report that hypershift must be enabled
-		r.StatusManager.AddComponent(status.NewDisabledStatus(
-			types.NamespacedName{Name: addon.GetName(), Namespace: addon.GetNamespace()},
-			"Local hosting only available when hypershift is enabled",
-			[]*unstructured.Unstructured{addon},
-		))
-		return r.removeHypershiftLocalHosting(ctx, mce)
-	}
-
-	if !mce.Enabled(backplanev1.LocalCluster) {
-		// report that local-cluster must be enabled
-		r.StatusManager.AddComponent(status.NewDisabledStatus(
-			types.NamespacedName{Name: addon.GetName(), Namespace: addon.GetNamespace()},
-			"Local hosting only available when local-cluster is enabled",
-			[]*unstructured.Unstructured{addon},
-		))
-		return r.removeHypershiftLocalHosting(ctx, mce)
-	}
-
 	localNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: mce.Spec.LocalClusterName}}
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: localNS.GetName()}, localNS)
 	if apierrors.IsNotFound(err) {
@@ -1906,6 +972,46 @@ func (r *MultiClusterEngineReconciler) applyHypershiftLocalHosting(ctx context.C
 	return ctrl.Result{}, nil
 }
 
+func (r *MultiClusterEngineReconciler) ensureNoHypershiftLocalHosting(ctx context.Context,
+	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+	addon, err := renderer.RenderHypershiftAddon(mce)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	namespacedName := types.NamespacedName{Name: addon.GetName(), Namespace: addon.GetNamespace()}
+	if !mce.Enabled(backplanev1.HypershiftLocalHosting) {
+		r.StatusManager.AddComponent(status.NewDisabledStatus(
+			namespacedName,
+			"Component is disabled",
+			[]*unstructured.Unstructured{addon},
+		))
+		return r.removeHypershiftLocalHosting(ctx, mce)
+	}
+
+	// Local hosting is enabled but blocked on hypershift or local-cluster
+	message := "Local hosting waiting for hypershift and local-cluster to be installed"
+	if !mce.Enabled(backplanev1.HyperShift) {
+		message = "Local hosting only available when hypershift is enabled"
+	} else if !mce.Enabled(backplanev1.LocalCluster) {
+		message = "Local hosting only available when local-cluster is enabled"
+	}
+	r.StatusManager.AddComponent(status.StaticStatus{
+		NamespacedName: namespacedName,
+		Kind:           addon.GetKind(),
+		Condition: backplanev1.ComponentCondition{
+			Type:      "NotPresent",
+			Name:      addon.GetName(),
+			Status:    metav1.ConditionTrue,
+			Reason:    status.DependencyBlockedReason,
+			Kind:      addon.GetKind(),
+			Available: true,
+			Message:   message,
+		},
+	})
+	return r.removeHypershiftLocalHosting(ctx, mce)
+}
+
 func (r *MultiClusterEngineReconciler) removeHypershiftLocalHosting(ctx context.Context,
 	mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
 	addon, err := renderer.RenderHypershiftAddon(mce)
@@ -1939,17 +1045,17 @@ func (r *MultiClusterEngineReconciler) ensureClusterProxyAddon(ctx context.Conte
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterProxyAddon)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.ClusterProxyAddon); err != nil {
+	if result, err := r.applyComponentDeploymentOverrides(ctx, mce, templates, backplanev1.ClusterProxyAddon); err != nil {
 		return result, err
 	}
 
@@ -1995,16 +1101,17 @@ func (r *MultiClusterEngineReconciler) ensureNoClusterProxyAddon(ctx context.Con
 
 	// Renders all templates from charts
 	chartPath := r.fetchChartOrCRDPath(backplanev1.ClusterProxyAddon)
-	templates, errs := renderer.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
+	templates, errs := r.renderCache.RenderChart(chartPath, mce, r.CacheSpec.ImageOverrides, r.CacheSpec.TemplateOverrides)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
-	// Deletes all templates
+	// Deletes all templates, in the reverse of the install order
+	renderer.SortForUninstall(templates)
 	for _, template := range templates {
 		// Skip NetworkPolicy resources - they are managed by ensureNetworkPolicies with create-once pattern
 		if template.GetKind() == "NetworkPolicy" {
@@ -2055,17 +1162,17 @@ func (r *MultiClusterEngineReconciler) ensureMaestro(ctx context.Context,
 
 	// Renders all templates from charts with maestro namespace
 	chartPath := r.fetchChartOrCRDPath(backplanev1.MaestroPreview)
-	templates, errs := renderer.RenderChartWithNamespace(chartPath, mce, r.CacheSpec.ImageOverrides,
+	templates, errs := r.renderCache.RenderChartWithNamespace(chartPath, mce, r.CacheSpec.ImageOverrides,
 		r.CacheSpec.TemplateOverrides, maestroName)
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info("failed to render the maestro chart", "err", err)
 		}
-		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		return renderFailure(errs)
 	}
 
 	// Apply deployment config overrides
-	if result, err := r.applyComponentDeploymentOverrides(mce, templates, backplanev1.MaestroPreview); err != nil {
+	if result, err := r.applyComponentDeploymentOverrides(ctx, mce, templates, backplanev1.MaestroPreview); err != nil {
 		return result, errors.Wrapf(err, "failed to apply deployment config overrides for maestro")
 	}
 
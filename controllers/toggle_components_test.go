@@ -72,23 +72,36 @@ func Test_reconcileLocalHosting(t *testing.T) {
 	}
 
 	// Hypershift not enabled
-	_, _ = r.reconcileHypershiftLocalHosting(ctx, mce)
+	localHosting, _ := lookupComponent(backplanev1.HypershiftLocalHosting)
+	unmet := r.unmetDependencies(ctx, mce, localHosting, map[string]bool{backplanev1.LocalCluster: true})
+	if !reflect.DeepEqual(unmet, []string{backplanev1.HyperShift}) {
+		t.Errorf("local hosting should be blocked on hypershift, got %v", unmet)
+	}
+
+	// LocalHosting not enabled
+	mce.Spec.Overrides.Components = []backplanev1.ComponentConfig{
+		{Name: backplanev1.HypershiftLocalHosting, Enabled: false},
+	}
+	_, _ = r.ensureNoHypershiftLocalHosting(ctx, mce)
 	mceStatus := r.StatusManager.ReportStatus(*mce)
 	component := getComponent(mceStatus.Components, "hypershift-addon")
 	if component.Type != "NotPresent" || component.Status != metav1.ConditionTrue || component.Reason != status.ComponentDisabledReason {
-		t.Error("component should not be present due to missing requirements")
+		t.Error("component should not be present because it is disabled")
 	}
 	r.StatusManager.Reset("")
 
-	// LocalHosting not enabled
+	// LocalHosting enabled but blocked on hypershift
 	mce.Spec.Overrides.Components = []backplanev1.ComponentConfig{
-		{Name: backplanev1.HypershiftLocalHosting, Enabled: false},
+		{Name: backplanev1.HypershiftLocalHosting, Enabled: true},
+		{Name: backplanev1.HyperShift, Enabled: false},
+		{Name: backplanev1.LocalCluster, Enabled: true},
 	}
-	_, _ = r.reconcileHypershiftLocalHosting(ctx, mce)
+	_, _ = r.ensureNoHypershiftLocalHosting(ctx, mce)
 	mceStatus = r.StatusManager.ReportStatus(*mce)
 	component = getComponent(mceStatus.Components, "hypershift-addon")
-	if component.Type != "NotPresent" || component.Status != metav1.ConditionTrue || component.Reason != status.ComponentDisabledReason {
-		t.Error("component should not be present because it is disabled")
+	if component.Reason != status.DependencyBlockedReason ||
+		component.Message != "Local hosting only available when hypershift is enabled" {
+		t.Errorf("component should be blocked on hypershift, got %s: %s", component.Reason, component.Message)
 	}
 	r.StatusManager.Reset("")
 
@@ -98,7 +111,7 @@ func Test_reconcileLocalHosting(t *testing.T) {
 		{Name: backplanev1.HyperShift, Enabled: true},
 		{Name: backplanev1.LocalCluster, Enabled: true},
 	}
-	_, _ = r.reconcileHypershiftLocalHosting(ctx, mce)
+	_, _ = r.ensureHypershiftLocalHosting(ctx, mce)
 	mceStatus = r.StatusManager.ReportStatus(*mce)
 	component = getComponent(mceStatus.Components, "hypershift-addon")
 	if component.Reason != status.WaitingForResourceReason {
@@ -129,7 +142,7 @@ func Test_reconcileLocalHosting(t *testing.T) {
 	// reconcile is not successful likely due to Server-Side Apply
 
 	// mock client patching isnt available, when resources are created by mock client, the status condition is not added by default
-	_, err = r.reconcileHypershiftLocalHosting(ctx, mce)
+	_, err = r.ensureHypershiftLocalHosting(ctx, mce)
 	if err != nil {
 		t.Errorf("error reconciling Hypershift addon: %s", err.Error())
 	}
@@ -484,7 +497,7 @@ func Test_applyTemplateWrappedError(t *testing.T) {
 		StatusManager: &status.StatusTracker{Client: wrappedClient},
 	}
 
-	// Call applyTemplate - this will return a wrapped NotFound error via logAndSetCondition
+	// Call applyTemplate - this will return a wrapped NotFound error via logApplyError
 	ctx := context.Background()
 	_, err := reconciler.applyTemplate(ctx, mce, template)
 
@@ -1570,8 +1583,7 @@ func fleetNavMCE() *backplanev1.MultiClusterEngine {
 }
 
 func Test_ensureFleetNavigation(t *testing.T) {
-	os.Setenv("DIRECTORY_OVERRIDE", "../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS(".."))
 
 	t.Run("creates InternalEngineComponent and renders templates", func(t *testing.T) {
 		ctx := context.TODO()
@@ -1616,8 +1628,7 @@ func Test_ensureFleetNavigation(t *testing.T) {
 }
 
 func Test_ensureNoFleetNavigation(t *testing.T) {
-	os.Setenv("DIRECTORY_OVERRIDE", "../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS(".."))
 
 	t.Run("deletes InternalEngineComponent and templates", func(t *testing.T) {
 		ctx := context.TODO()
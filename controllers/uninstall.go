@@ -18,7 +18,9 @@ import (
 
 var (
 	// The uninstallList is the list of all resources from previous installs to remove. Items can be removed
-	// from this list in future releases if they are sure to not exist prior to the current installer version
+	// from this list in future releases if they are sure to not exist prior to the current installer version.
+	// Resources recorded in the applied-resource inventory are pruned automatically once they are no longer
+	// rendered (see pruneInventory), so only resources applied before the inventory existed need to be listed.
 	uninstallList = func(backplaneConfig *backplanev1.MultiClusterEngine) []*unstructured.Unstructured {
 		removals := []*unstructured.Unstructured{
 			newUnstructured(
@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Command values-schema writes the values.schema.json of every chart in the directories it is given, generated
+// from the values.yaml and templates of the chart.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: values-schema <chart directory>...")
+		os.Exit(2)
+	}
+	for _, dir := range os.Args[1:] {
+		if err := writeSchemas(dir); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// writeSchemas writes the schema of dir if it is a chart, or of the charts directly in it otherwise.
+func writeSchemas(dir string) error {
+	charts := []string{dir}
+	if _, err := os.Stat(filepath.Join(dir, "Chart.yaml")); err != nil {
+		charts, err = filepath.Glob(filepath.Join(dir, "*", "Chart.yaml"))
+		if err != nil {
+			return err
+		}
+		for i := range charts {
+			charts[i] = filepath.Dir(charts[i])
+		}
+	}
+	for _, chart := range charts {
+		schema, err := renderer.ValuesSchema(os.DirFS(chart), ".")
+		if err != nil {
+			return fmt.Errorf("failed to generate the values schema of %s: %w", chart, err)
+		}
+		if err := os.WriteFile(filepath.Join(chart, "values.schema.json"), schema, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -23,8 +23,11 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"embed"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -38,6 +41,8 @@ import (
 	operatorsapiv2 "github.com/operator-framework/api/pkg/operators/v2"
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
 	"github.com/stolostron/backplane-operator/controllers"
+	"github.com/stolostron/backplane-operator/pkg/manifest"
+	"github.com/stolostron/backplane-operator/pkg/overrides"
 	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/status"
 	"github.com/stolostron/backplane-operator/pkg/utils"
@@ -70,6 +75,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/yaml"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	// +kubebuilder:scaffold:imports
@@ -87,13 +93,13 @@ const (
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+
+	// templates holds the charts, CRDs and add-on templates the operator deploys
+	//go:embed pkg/templates/charts pkg/templates/crds pkg/templates/clustermanagementaddons
+	templates embed.FS
 )
 
 func init() {
-	if _, exists := os.LookupEnv("OPERATOR_VERSION"); !exists {
-		panic("OPERATOR_VERSION not defined")
-	}
-
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(backplanev1.AddToScheme(scheme))
@@ -119,6 +125,101 @@ func init() {
 	utilruntime.Must(addonv1alpha1.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
+
+	renderer.SetTemplateFS(templates)
+}
+
+/*
+runRender renders the resources the operator deploys for a MultiClusterEngine without connecting to a
+cluster, and writes them as sorted YAML documents. The image and template overrides are read from
+files in the JSON formats of the image and template override ConfigMaps.
+*/
+func runRender(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	mceFile := flags.String("f", "", "The MultiClusterEngine to render, in YAML or JSON.")
+	imagesFile := flags.String("images", "", "The image manifest, as a JSON list of images.")
+	templatesFile := flags.String("templates", "", "The template overrides, as a JSON templateOverrides object.")
+	ocpVersion := flags.String("ocp-version", "", "The OpenShift version of the hub cluster.")
+	openshift := flags.Bool("openshift", true, "Render for an OpenShift hub cluster.")
+	output := flags.String("o", "", "The file to write the resources to. Defaults to standard output.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *mceFile == "" {
+		return fmt.Errorf("a MultiClusterEngine is required (-f)")
+	}
+
+	ctrl.SetLogger(zap.New())
+	utils.SetDeployOnOCP(*openshift)
+	if *ocpVersion != "" {
+		if err := os.Setenv("ACM_HUB_OCP_VERSION", *ocpVersion); err != nil {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(*mceFile)
+	if err != nil {
+		return err
+	}
+	mce := &backplanev1.MultiClusterEngine{}
+	if err := yaml.Unmarshal(data, mce); err != nil {
+		return fmt.Errorf("failed to parse MultiClusterEngine %s: %w", *mceFile, err)
+	}
+
+	images := map[string]string{}
+	if *imagesFile != "" {
+		data, err := os.ReadFile(*imagesFile)
+		if err != nil {
+			return err
+		}
+		var manifestImages []manifest.ManifestImage
+		if err := json.Unmarshal(data, &manifestImages); err != nil {
+			return fmt.Errorf("failed to parse image manifest %s: %w", *imagesFile, err)
+		}
+		if err := overrides.ConvertImageOverrides(images, manifestImages); err != nil {
+			return err
+		}
+	}
+
+	templateOverrides := map[string]string{}
+	if *templatesFile != "" {
+		data, err := os.ReadFile(*templatesFile)
+		if err != nil {
+			return err
+		}
+		var manifestTemplate manifest.ManifestTemplate
+		if err := json.Unmarshal(data, &manifestTemplate); err != nil {
+			return fmt.Errorf("failed to parse template overrides %s: %w", *templatesFile, err)
+		}
+		if err := overrides.ConvertTemplateOverrides(templateOverrides, manifestTemplate); err != nil {
+			return err
+		}
+	}
+
+	manifests, err := controllers.RenderManifests(mce, images, templateOverrides, *ocpVersion)
+	if err != nil {
+		return err
+	}
+
+	out := stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	for _, m := range manifests {
+		data, err := yaml.Marshal(m.Object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func detectOLMVersion(ctx context.Context, cl client.Client) (string, error) {
@@ -152,6 +253,18 @@ func detectOLMVersion(ctx context.Context, cl client.Client) (string, error) {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		if err := runRender(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if _, exists := os.LookupEnv("OPERATOR_VERSION"); !exists {
+		panic("OPERATOR_VERSION not defined")
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
@@ -278,12 +391,19 @@ func main() {
 		&corev1.ConfigMap{},
 		&corev1.ServiceAccount{},
 	}
+	// The kinds not cached by the client are only watched for the resources labeled for a component
+	mgrOptions.Cache.ByObject, err = controllers.ComponentCacheByObject(mgrOptions.Client.Cache.DisableFor)
+	if err != nil {
+		setupLog.Error(err, "unable to configure the cache")
+		os.Exit(1)
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	renderer.SetRESTMapper(mgr.GetRESTMapper())
 	ctx = ctrl.SetupSignalHandler()
 	upgradeableCondition := &utils.OperatorCondition{}
 
@@ -351,18 +471,28 @@ func main() {
 	}
 	setupLog.Info("Component CRDs applied successfully")
 
-	if err = (&controllers.MultiClusterEngineReconciler{
+	mceReconciler := &controllers.MultiClusterEngineReconciler{
 		Client:          mgr.GetClient(),
 		Scheme:          mgr.GetScheme(),
 		UncachedClient:  uncachedClient,
 		StatusManager:   &status.StatusTracker{Client: mgr.GetClient()},
+		Recorder:        mgr.GetEventRecorder("multiclusterengine-controller"),
 		UpgradeableCond: upgradeableCondition,
 		OLMVersion:      olmVersion,
-	}).SetupWithManager(mgr); err != nil {
+	}
+	if err = mceReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MultiClusterEngine")
 		os.Exit(1)
 	}
 
+	if err = (&controllers.InternalEngineComponentReconciler{
+		Client: mgr.GetClient(),
+		Engine: mceReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "InternalEngineComponent")
+		os.Exit(1)
+	}
+
 	if !utils.DeployOnOCP() {
 		kubeClient, err := kubernetes.NewForConfig(ctrl.GetConfigOrDie())
 		if err != nil {
@@ -407,6 +537,8 @@ func main() {
 			os.Exit(1)
 		}
 
+		// Components about to be enabled are rendered to count them against the footprint budget
+		backplanev1.ComponentFootprintFunc = mcewebhook.ComponentFootprint
 		if err = (&backplanev1.MultiClusterEngine{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "MultiClusterEngine")
 			os.Exit(1)
@@ -0,0 +1,143 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stolostron/backplane-operator/pkg/manifest"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+)
+
+func Test_runRender(t *testing.T) {
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+	t.Cleanup(func() { utils.SetDeployOnOCP(true) })
+
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	manifestImages := []manifest.ManifestImage{}
+	for _, image := range utils.GetTestImages() {
+		manifestImages = append(manifestImages, manifest.ManifestImage{
+			ImageKey:    strings.ToLower(image),
+			ImageRemote: "quay.io/test",
+			ImageName:   "test",
+			ImageTag:    "test",
+		})
+	}
+	imagesData, err := json.Marshal(manifestImages)
+	if err != nil {
+		t.Fatalf("failed to marshal the image manifest: %v", err)
+	}
+
+	mce := write("mce.yaml", "apiVersion: multicluster.openshift.io/v1\nkind: MultiClusterEngine\n"+
+		"metadata:\n  name: multiclusterengine\nspec:\n  targetNamespace: test-engine\n")
+	images := write("images.json", string(imagesData))
+	templates := write("templates.json",
+		`{"templateOverrides": {"console_mce_deployment_container_memory_limit": "512Mi"}}`)
+	invalidMCE := write("invalid.yaml", "spec: [")
+	invalidImages := write("invalid-images.json", `[{"image-name": "test"}]`)
+	invalidTemplates := write("invalid-templates.json", `{"templateOverrides": "512Mi"}`)
+	output := filepath.Join(dir, "manifests.yaml")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+		// wantOutput is the file the manifests are written to, standard output if empty
+		wantOutput string
+	}{
+		{
+			name: "render to standard output",
+			args: []string{"-f", mce, "-images", images, "-templates", templates},
+		},
+		{
+			name:       "render to a file",
+			args:       []string{"-f", mce, "-images", images, "-ocp-version", "4.16.0", "-o", output},
+			wantOutput: output,
+		},
+		{
+			name: "render for a Kubernetes hub",
+			args: []string{"-f", mce, "-images", images, "-openshift=false"},
+		},
+		{
+			name:    "missing MultiClusterEngine",
+			args:    []string{"-images", images},
+			wantErr: "a MultiClusterEngine is required",
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"-f", mce, "-unknown"},
+			wantErr: "flag provided but not defined",
+		},
+		{
+			name:    "MultiClusterEngine not found",
+			args:    []string{"-f", filepath.Join(dir, "missing.yaml")},
+			wantErr: "no such file or directory",
+		},
+		{
+			name:    "invalid MultiClusterEngine",
+			args:    []string{"-f", invalidMCE},
+			wantErr: "failed to parse MultiClusterEngine",
+		},
+		{
+			name:    "invalid image manifest",
+			args:    []string{"-f", mce, "-images", invalidImages},
+			wantErr: "missing or empty ImageKey",
+		},
+		{
+			name:    "invalid template overrides",
+			args:    []string{"-f", mce, "-images", images, "-templates", invalidTemplates},
+			wantErr: "failed to parse template overrides",
+		},
+		{
+			name:    "output directory not found",
+			args:    []string{"-f", mce, "-images", images, "-o", filepath.Join(dir, "missing", "manifests.yaml")},
+			wantErr: "no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			err := runRender(tt.args, stdout)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runRender() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runRender() returned error: %v", err)
+			}
+
+			rendered := stdout.String()
+			if tt.wantOutput != "" {
+				if rendered != "" {
+					t.Errorf("expected nothing to be written to standard output")
+				}
+				data, err := os.ReadFile(tt.wantOutput)
+				if err != nil {
+					t.Fatalf("failed to read the output: %v", err)
+				}
+				rendered = string(data)
+			}
+			if !strings.HasPrefix(rendered, "---\n") ||
+				!strings.Contains(rendered, "name: ocm-controller") ||
+				!strings.Contains(rendered, "namespace: test-engine") {
+				t.Errorf("expected the manifests of the MultiClusterEngine to be rendered, got %d bytes",
+					len(rendered))
+			}
+		})
+	}
+}
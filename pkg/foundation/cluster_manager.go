@@ -8,10 +8,8 @@ package foundation
 
 import (
 	"context"
-	"io"
-	"os"
+	"io/fs"
 	"path"
-	"path/filepath"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -22,6 +20,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	v1 "github.com/stolostron/backplane-operator/api/v1"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/utils"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ocmapiv1 "open-cluster-management.io/api/operator/v1"
@@ -71,6 +70,22 @@ func ClusterManager(m *v1.MultiClusterEngine, overrides map[string]string) *unst
 	} else {
 		cmTolerations = utils.DefaultTolerations()
 	}
+	cmNodeSelector := m.Spec.NodeSelector
+
+	// The scheduling overrides of the cluster-manager component take precedence over the global ones.
+	if m.Spec.Overrides != nil {
+		for _, c := range m.Spec.Overrides.Components {
+			if c.Name != v1.ClusterManager {
+				continue
+			}
+			if c.NodeSelector != nil {
+				cmNodeSelector = c.NodeSelector
+			}
+			if len(c.Tolerations) > 0 {
+				cmTolerations = c.Tolerations
+			}
+		}
+	}
 
 	cm := &ocmapiv1.ClusterManager{
 		TypeMeta: metav1.TypeMeta{
@@ -86,7 +101,7 @@ func ClusterManager(m *v1.MultiClusterEngine, overrides map[string]string) *unst
 			PlacementImagePullSpec:    PlacementImage(overrides),
 			AddOnManagerImagePullSpec: AddonManagerImage(overrides),
 			NodePlacement: ocmapiv1.NodePlacement{
-				NodeSelector: m.Spec.NodeSelector,
+				NodeSelector: cmNodeSelector,
 				Tolerations:  cmTolerations,
 			},
 			DeployOption: ocmapiv1.ClusterManagerDeployOption{
@@ -140,37 +155,16 @@ func CanInstallAddons(ctx context.Context, client client.Client) bool {
 func GetAddons() ([]*unstructured.Unstructured, error) {
 	var addons []*unstructured.Unstructured
 
-	addonPath := addonPath
-	if val, ok := os.LookupEnv("DIRECTORY_OVERRIDE"); ok {
-		addonPath = path.Join(val, addonPath)
-	}
-
-	root, err := os.OpenRoot(addonPath)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = root.Close() }()
-
-	err = filepath.Walk(addonPath, func(filePath string, info os.FileInfo, err error) error {
+	templates := renderer.TemplateFS()
+	err := fs.WalkDir(templates, path.Clean(addonPath), func(filePath string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if info == nil || info.IsDir() {
+		if d.IsDir() {
 			return nil
 		}
 
-		relPath, err := filepath.Rel(addonPath, filePath)
-		if err != nil {
-			return err
-		}
-
-		f, err := root.Open(relPath)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-
-		bytesFile, err := io.ReadAll(f)
+		bytesFile, err := fs.ReadFile(templates, filePath)
 		if err != nil {
 			return err
 		}
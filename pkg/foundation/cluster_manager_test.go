@@ -11,6 +11,7 @@ import (
 	ocmapiv1 "open-cluster-management.io/api/operator/v1"
 
 	v1 "github.com/stolostron/backplane-operator/api/v1"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 )
 
 func TestClusterManager(t *testing.T) {
@@ -53,13 +54,37 @@ func TestClusterManager(t *testing.T) {
 			expectedPlacementImage:    "quay.io/stolostron/placement@sha256:8d69eb89ee008bf95c2b877887e66cc1541c2407c9d7339fff8a9a973200660f",
 			expectedAddonManagerImage: "quay.io/stolostron/addon-manager@sha256:8d69eb89ee008bf95c2b877887e66cc1541c2407c9d7339fff8a9a973200660e",
 		},
+		{
+			name: "create cluster manager with a component nodeSelector",
+			mce: &v1.MultiClusterEngine{Spec: v1.MultiClusterEngineSpec{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				Overrides: &v1.Overrides{Components: []v1.ComponentConfig{{
+					Name:         v1.ClusterManager,
+					Enabled:      true,
+					NodeSelector: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+				}}},
+			}},
+			imageOverrides: map[string]string{
+				"registration":  "quay.io/stolostron/registration@sha256:fe95bca419976ca8ffe608bc66afcead6ef333b863f22be55df57c89ded75dda",
+				"work":          "quay.io/stolostron/work@sha256:856d2151423f020952d9b9253676c1c4d462fab6722c8af4885fe2b19ccd1be0",
+				"placement":     "quay.io/stolostron/placement@sha256:8d69eb89ee008bf95c2b877887e66cc1541c2407c9d7339fff8a9a973200660f",
+				"addon_manager": "quay.io/stolostron/addon-manager@sha256:8d69eb89ee008bf95c2b877887e66cc1541c2407c9d7339fff8a9a973200660e",
+			},
+			expectedNodeSelector:      map[string]string{"node-role.kubernetes.io/control-plane": ""},
+			expectedRegistrationImage: "quay.io/stolostron/registration@sha256:fe95bca419976ca8ffe608bc66afcead6ef333b863f22be55df57c89ded75dda",
+			expectedWorkImage:         "quay.io/stolostron/work@sha256:856d2151423f020952d9b9253676c1c4d462fab6722c8af4885fe2b19ccd1be0",
+			expectedPlacementImage:    "quay.io/stolostron/placement@sha256:8d69eb89ee008bf95c2b877887e66cc1541c2407c9d7339fff8a9a973200660f",
+			expectedAddonManagerImage: "quay.io/stolostron/addon-manager@sha256:8d69eb89ee008bf95c2b877887e66cc1541c2407c9d7339fff8a9a973200660e",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			c := ClusterManager(test.mce, test.imageOverrides)
 
-			os.Setenv("DIRECTORY_OVERRIDE", "../../")
+			saved := renderer.TemplateFS()
+			renderer.SetTemplateFS(os.DirFS("../../"))
+			defer renderer.SetTemplateFS(saved)
 
 			_, err := GetAddons()
 			if err != nil {
@@ -105,6 +130,9 @@ func TestClusterManager(t *testing.T) {
 				t.Errorf("expected cluster manager NodeSelector not found")
 			}
 
+			if len(nodeSelector) != len(test.expectedNodeSelector) {
+				t.Errorf("expected NodeSelector %s, got %s", test.expectedNodeSelector, nodeSelector)
+			}
 			for k, v := range test.expectedNodeSelector {
 				if nodeSelector[k] != v {
 					t.Errorf("expected NodeSelector %s, got %s", test.expectedNodeSelector, nodeSelector)
@@ -0,0 +1,133 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"fmt"
+
+	v1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	hostnameTopologyKey = "kubernetes.io/hostname"
+	zoneTopologyKey     = "topology.kubernetes.io/zone"
+)
+
+/*
+applyHighAvailability spreads the pods of every Deployment of the templates across nodes and zones and
+adds a PodDisruptionBudget for it, so that draining a node never takes down every replica at once.
+Deployments keep the pod anti-affinity and topology spread constraints their chart sets, and no
+PodDisruptionBudget is added for Deployments whose pods a PodDisruptionBudget of the chart selects.
+*/
+func applyHighAvailability(templates []*unstructured.Unstructured,
+	backplaneConfig *v1.MultiClusterEngine) ([]*unstructured.Unstructured, error) {
+
+	budgets := []labels.Selector{}
+	for _, template := range templates {
+		if template.GetKind() != "PodDisruptionBudget" {
+			continue
+		}
+		matchLabels, _, err := unstructured.NestedStringMap(template.Object, "spec", "selector", "matchLabels")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the selector of PodDisruptionBudget %s: %w", template.GetName(), err)
+		}
+		budgets = append(budgets, labels.SelectorFromSet(matchLabels))
+	}
+
+	added := []*unstructured.Unstructured{}
+	for _, template := range templates {
+		if template.GetKind() != "Deployment" {
+			continue
+		}
+		selector, found, err := unstructured.NestedMap(template.Object, "spec", "selector")
+		if err != nil || !found {
+			return nil, fmt.Errorf("failed to read the selector of Deployment %s: %v", template.GetName(), err)
+		}
+
+		if err := spreadPods(template, selector); err != nil {
+			return nil, err
+		}
+
+		podLabels, _, _ := unstructured.NestedStringMap(template.Object, "spec", "template", "metadata", "labels")
+		budgeted := false
+		for _, budget := range budgets {
+			if !budget.Empty() && budget.Matches(labels.Set(podLabels)) {
+				budgeted = true
+				break
+			}
+		}
+		if !budgeted {
+			added = append(added, podDisruptionBudget(template, selector, backplaneConfig))
+		}
+	}
+	return append(templates, added...), nil
+}
+
+// spreadPods adds a preferred pod anti-affinity across nodes and a topology spread constraint across zones
+// to the pods of the Deployment, unless its chart sets them.
+func spreadPods(template *unstructured.Unstructured, selector map[string]interface{}) error {
+	podSpec := []string{"spec", "template", "spec"}
+
+	if _, found, _ := unstructured.NestedFieldNoCopy(template.Object,
+		append(podSpec, "affinity", "podAntiAffinity")...); !found {
+		antiAffinity := map[string]interface{}{
+			"preferredDuringSchedulingIgnoredDuringExecution": []interface{}{
+				map[string]interface{}{
+					"weight": int64(100),
+					"podAffinityTerm": map[string]interface{}{
+						"labelSelector": selector,
+						"topologyKey":   hostnameTopologyKey,
+					},
+				},
+			},
+		}
+		if err := unstructured.SetNestedMap(template.Object, antiAffinity,
+			append(podSpec, "affinity", "podAntiAffinity")...); err != nil {
+			return fmt.Errorf("failed to set the pod anti-affinity of Deployment %s: %w", template.GetName(), err)
+		}
+	}
+
+	if _, found, _ := unstructured.NestedFieldNoCopy(template.Object,
+		append(podSpec, "topologySpreadConstraints")...); !found {
+		constraints := []interface{}{
+			map[string]interface{}{
+				"maxSkew":           int64(1),
+				"topologyKey":       zoneTopologyKey,
+				"whenUnsatisfiable": "ScheduleAnyway",
+				"labelSelector":     selector,
+			},
+		}
+		if err := unstructured.SetNestedSlice(template.Object, constraints,
+			append(podSpec, "topologySpreadConstraints")...); err != nil {
+			return fmt.Errorf("failed to set the topology spread constraints of Deployment %s: %w",
+				template.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// podDisruptionBudget returns a PodDisruptionBudget that lets at most one pod of the Deployment be evicted at a time.
+func podDisruptionBudget(deployment *unstructured.Unstructured, selector map[string]interface{},
+	backplaneConfig *v1.MultiClusterEngine) *unstructured.Unstructured {
+
+	pdb := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "policy/v1",
+			"kind":       "PodDisruptionBudget",
+			"metadata": map[string]interface{}{
+				"name":      deployment.GetName(),
+				"namespace": deployment.GetNamespace(),
+			},
+			"spec": map[string]interface{}{
+				"maxUnavailable": int64(1),
+				"selector":       selector,
+			},
+		},
+	}
+	pdb.SetLabels(deployment.GetLabels())
+	utils.AddBackplaneConfigLabels(pdb, backplaneConfig.Name)
+	return pdb
+}
@@ -0,0 +1,145 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"os"
+	"testing"
+
+	v1 "github.com/stolostron/backplane-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRenderHighAvailability(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+
+	for _, availability := range []v1.AvailabilityType{v1.HABasic, v1.HAHigh} {
+		t.Run(string(availability), func(t *testing.T) {
+			mce := &v1.MultiClusterEngine{
+				ObjectMeta: metav1.ObjectMeta{Name: "testBackplaneConfig"},
+				Spec: v1.MultiClusterEngineSpec{
+					TargetNamespace:    "multicluster-engine",
+					AvailabilityConfig: availability,
+				},
+			}
+			templates, errs := RenderChart("pkg/templates/charts/toggle/discovery-operator", mce,
+				map[string]string{"discovery_operator": "quay.io/test/discovery:test"}, map[string]string{})
+			if len(errs) > 0 {
+				t.Fatalf("RenderChart() returned errors: %v", errs)
+			}
+
+			var deployment *appsv1.Deployment
+			var budget *policyv1.PodDisruptionBudget
+			for _, template := range templates {
+				switch template.GetKind() {
+				case "Deployment":
+					deployment = &appsv1.Deployment{}
+					if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+						t.Fatalf("failed to convert the Deployment: %v", err)
+					}
+				case "PodDisruptionBudget":
+					budget = &policyv1.PodDisruptionBudget{}
+					if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, budget); err != nil {
+						t.Fatalf("failed to convert the PodDisruptionBudget: %v", err)
+					}
+				}
+			}
+			if deployment == nil {
+				t.Fatalf("expected the discovery-operator Deployment to be rendered")
+			}
+
+			if availability == v1.HABasic {
+				if budget != nil || len(deployment.Spec.Template.Spec.TopologySpreadConstraints) > 0 {
+					t.Errorf("expected no PodDisruptionBudget nor topology spread with availability %s", availability)
+				}
+				return
+			}
+
+			if budget == nil {
+				t.Fatalf("expected a PodDisruptionBudget with availability %s", availability)
+			}
+			if budget.Name != deployment.Name || budget.Namespace != deployment.Namespace {
+				t.Errorf("expected the PodDisruptionBudget %s/%s to be named after the Deployment %s/%s",
+					budget.Namespace, budget.Name, deployment.Namespace, deployment.Name)
+			}
+			if budget.Spec.MaxUnavailable == nil || budget.Spec.MaxUnavailable.IntValue() != 1 {
+				t.Errorf("expected the PodDisruptionBudget to allow one unavailable pod, got %v", budget.Spec.MaxUnavailable)
+			}
+			selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+			if err != nil || !selector.Matches(labels.Set(deployment.Spec.Template.Labels)) {
+				t.Errorf("expected the PodDisruptionBudget to select the pods of the Deployment")
+			}
+			if budget.Labels["backplaneconfig.name"] != mce.Name {
+				t.Errorf("expected the PodDisruptionBudget to be labeled for the MultiClusterEngine")
+			}
+
+			// The chart sets its own pod anti-affinity, which is kept.
+			antiAffinity := deployment.Spec.Template.Spec.Affinity.PodAntiAffinity
+			if len(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 2 {
+				t.Errorf("expected the pod anti-affinity of the chart to be kept, got %v", antiAffinity)
+			}
+			constraints := deployment.Spec.Template.Spec.TopologySpreadConstraints
+			if len(constraints) != 1 || constraints[0].TopologyKey != zoneTopologyKey || constraints[0].MaxSkew != 1 {
+				t.Errorf("expected the pods to be spread across zones, got %v", constraints)
+			}
+		})
+	}
+}
+
+func TestApplyHighAvailability(t *testing.T) {
+	mce := &v1.MultiClusterEngine{ObjectMeta: metav1.ObjectMeta{Name: "mce"}}
+	deployment := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "ns"},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": name}},
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": name}},
+					"spec":     map[string]interface{}{"containers": []interface{}{}},
+				},
+			},
+		}
+	}
+	templates := []*unstructured.Unstructured{{Object: deployment("budgeted")}, {Object: deployment("spread")}, {Object: map[string]interface{}{
+		"apiVersion": "policy/v1",
+		"kind":       "PodDisruptionBudget",
+		"metadata":   map[string]interface{}{"name": "budgeted-pdb", "namespace": "ns"},
+		"spec": map[string]interface{}{
+			"minAvailable": int64(1),
+			"selector":     map[string]interface{}{"matchLabels": map[string]interface{}{"app": "budgeted"}},
+		},
+	}}}
+
+	templates, err := applyHighAvailability(templates, mce)
+	if err != nil {
+		t.Fatalf("applyHighAvailability() = %v", err)
+	}
+
+	budgets := map[string]bool{}
+	for _, template := range templates {
+		if template.GetKind() == "PodDisruptionBudget" {
+			budgets[template.GetName()] = true
+		}
+	}
+	if len(budgets) != 2 || !budgets["budgeted-pdb"] || !budgets["spread"] {
+		t.Errorf("expected a PodDisruptionBudget to be added only for the Deployment without one, got %v", budgets)
+	}
+
+	spread := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(templates[1].Object, spread); err != nil {
+		t.Fatalf("failed to convert the Deployment: %v", err)
+	}
+	terms := spread.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 1 || terms[0].PodAffinityTerm.TopologyKey != hostnameTopologyKey ||
+		terms[0].PodAffinityTerm.LabelSelector.MatchLabels["app"] != "spread" {
+		t.Errorf("expected the pods to prefer different nodes, got %v", terms)
+	}
+}
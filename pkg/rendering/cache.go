@@ -0,0 +1,115 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	v1 "github.com/stolostron/backplane-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+/*
+RenderCache renders each chart once for a set of inputs and shares the output between the callers,
+so that the NetworkPolicy, apply and delete paths of a reconcile do not render the same chart again.
+Charts are keyed by their path and target namespace, and are rendered again when the effective values,
+the name or the generation of the MultiClusterEngine differ from those the cached output was rendered
+with. Output with resources of kinds the cluster does not know is not cached, as it is scoped again once
+their CRDs are installed. The lock only guards the cached charts, so that charts are rendered in parallel; callers rendering
+the same chart at the same time may each render it. The zero value is ready to use.
+*/
+type RenderCache struct {
+	mu     sync.Mutex
+	charts map[string]renderedChart
+}
+
+type renderedChart struct {
+	fingerprint string
+	templates   []*unstructured.Unstructured
+}
+
+// RenderChart renders the chart like RenderChart, returning the cached output if the inputs are unchanged.
+func (c *RenderCache) RenderChart(chartPath string, backplaneConfig *v1.MultiClusterEngine,
+	images map[string]string, templates map[string]string) ([]*unstructured.Unstructured, []error) {
+
+	values := renderValues(backplaneConfig, images, templates)
+	key := chartPath + "/" + backplaneConfig.Spec.TargetNamespace
+	fingerprint, err := renderFingerprint(backplaneConfig, values)
+	if err != nil {
+		return renderTemplates(chartPath, backplaneConfig, values)
+	}
+
+	c.mu.Lock()
+	cached, ok := c.charts[key]
+	c.mu.Unlock()
+	if ok && cached.fingerprint == fingerprint {
+		return copyTemplates(cached.templates), nil
+	}
+
+	rendered, errs := renderTemplates(chartPath, backplaneConfig, values)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	// Resources of kinds the cluster does not know yet are not scoped, so the chart is rendered again
+	// until their CRDs are installed
+	if hasUnmappedKinds(rendered) {
+		return rendered, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.charts == nil {
+		c.charts = map[string]renderedChart{}
+	}
+	c.charts[key] = renderedChart{fingerprint: fingerprint, templates: copyTemplates(rendered)}
+	return rendered, nil
+}
+
+// RenderChartWithNamespace wraps the RenderChart method, overriding the target namespace
+func (c *RenderCache) RenderChartWithNamespace(chartPath string, backplaneConfig *v1.MultiClusterEngine,
+	images map[string]string, templates map[string]string, namespace string) ([]*unstructured.Unstructured, []error) {
+
+	mce := backplaneConfig.DeepCopy()
+	mce.Spec.TargetNamespace = namespace
+	return c.RenderChart(chartPath, mce, images, templates)
+}
+
+// renderFingerprint returns a hash of the inputs a chart of the MultiClusterEngine is rendered with.
+func renderFingerprint(backplaneConfig *v1.MultiClusterEngine, values *Values) (string, error) {
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.New()
+	sum.Write([]byte(backplaneConfig.GetName()))
+	sum.Write([]byte(strconv.FormatInt(backplaneConfig.GetGeneration(), 10)))
+	sum.Write(raw)
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// hasUnmappedKinds reports whether a template is of a kind the RESTMapper does not know.
+func hasUnmappedKinds(templates []*unstructured.Unstructured) bool {
+	for _, template := range templates {
+		if template.GetNamespace() != "" {
+			continue
+		}
+		if _, err := isNamespaced(template); meta.IsNoMatchError(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// copyTemplates returns a deep copy of the templates, which callers are free to modify.
+func copyTemplates(templates []*unstructured.Unstructured) []*unstructured.Unstructured {
+	copied := make([]*unstructured.Unstructured, len(templates))
+	for i, template := range templates {
+		copied[i] = template.DeepCopy()
+	}
+	return copied
+}
@@ -0,0 +1,117 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+
+	backplane "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderCache(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce", Generation: 1},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "default"},
+	}
+	images := map[string]string{"managed_serviceaccount": "quay.io/managed-serviceaccount:1.0"}
+	cache := &RenderCache{}
+
+	first, errs := cache.RenderChart(chartsPath, mce, images, map[string]string{})
+	if len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors: %v", errs)
+	}
+	uncached, _ := RenderChart(chartsPath, mce, images, map[string]string{})
+	if len(first) == 0 || len(first) != len(uncached) {
+		t.Fatalf("expected the cached chart to render %d templates, got %d", len(uncached), len(first))
+	}
+	fingerprint := cache.charts[chartsPath+"/default"].fingerprint
+
+	// Callers modify the templates they are given, which must not change the cached output
+	first[0].SetName("modified")
+	second, _ := cache.RenderChart(chartsPath, mce, images, map[string]string{})
+	if second[0].GetName() == "modified" {
+		t.Errorf("expected the cached templates to be copied for each caller")
+	}
+	if cache.charts[chartsPath+"/default"].fingerprint != fingerprint {
+		t.Errorf("expected unchanged inputs to reuse the rendered chart")
+	}
+
+	// Changed inputs render the chart again
+	images["managed_serviceaccount"] = "quay.io/managed-serviceaccount:2.0"
+	third, _ := cache.RenderChart(chartsPath, mce, images, map[string]string{})
+	if reflect.DeepEqual(second, third) {
+		t.Errorf("expected changed image overrides to render the chart again")
+	}
+	fingerprint = cache.charts[chartsPath+"/default"].fingerprint
+	mce.SetGeneration(2)
+	if _, errs := cache.RenderChart(chartsPath, mce, images, map[string]string{}); len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors: %v", errs)
+	}
+	if cache.charts[chartsPath+"/default"].fingerprint == fingerprint {
+		t.Errorf("expected a new generation of the MultiClusterEngine to render the chart again")
+	}
+
+	// Each target namespace is cached separately
+	namespaced, _ := cache.RenderChartWithNamespace(chartsPath, mce, images, map[string]string{}, "other")
+	for _, template := range namespaced {
+		if template.GetKind() == "Deployment" && template.GetNamespace() != "other" {
+			t.Errorf("expected the chart to be rendered in namespace other, got %s", template.GetNamespace())
+		}
+	}
+	if len(cache.charts) != 2 {
+		t.Errorf("expected 2 cached charts, got %d", len(cache.charts))
+	}
+}
+
+func TestRenderCacheConcurrent(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce", Generation: 1},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "default"},
+	}
+	images := map[string]string{
+		"managed_serviceaccount": "quay.io/managed-serviceaccount:1.0",
+		"discovery_operator":     "quay.io/discovery-operator:1.0",
+	}
+	charts := []string{chartsPath, "pkg/templates/charts/toggle/discovery-operator"}
+	want := map[string]int{}
+	for _, chart := range charts {
+		uncached, errs := RenderChart(chart, mce, images, map[string]string{})
+		if len(errs) > 0 {
+			t.Fatalf("RenderChart() returned errors: %v", errs)
+		}
+		want[chart] = len(uncached)
+	}
+
+	cache := &RenderCache{}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		for _, chart := range charts {
+			wg.Add(1)
+			go func(chart string) {
+				defer wg.Done()
+				rendered, errs := cache.RenderChart(chart, mce, images, map[string]string{})
+				if len(errs) > 0 {
+					t.Errorf("RenderChart() returned errors: %v", errs)
+					return
+				}
+				if len(rendered) != want[chart] {
+					t.Errorf("expected %s to render %d templates, got %d", chart, want[chart], len(rendered))
+				}
+			}(chart)
+		}
+	}
+	wg.Wait()
+
+	if len(cache.charts) != len(charts) {
+		t.Errorf("expected %d cached charts, got %d", len(charts), len(cache.charts))
+	}
+}
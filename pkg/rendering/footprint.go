@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"fmt"
+
+	v1 "github.com/stolostron/backplane-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+/*
+Footprint returns the total of the replicas, containers and resources of the Deployments of the templates
+of a component. The resources of the containers are counted once per replica, and Deployments without
+replicas set count one replica. Init containers, which do not run alongside the other containers, are
+left out.
+*/
+func Footprint(component string, templates []*unstructured.Unstructured) (v1.ComponentFootprint, error) {
+	footprint := v1.ComponentFootprint{
+		Name:     component,
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+	for _, template := range templates {
+		if template.GetKind() != "Deployment" {
+			continue
+		}
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+			return footprint, fmt.Errorf("failed to read Deployment %s: %w", template.GetName(), err)
+		}
+
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		footprint.Deployments++
+		footprint.Replicas += replicas
+
+		containers := deployment.Spec.Template.Spec.Containers
+		footprint.Containers += int32(len(containers)) * replicas
+		for _, container := range containers {
+			addResources(footprint.Requests, container.Resources.Requests, replicas)
+			addResources(footprint.Limits, container.Resources.Limits, replicas)
+		}
+	}
+	return footprint, nil
+}
+
+// addResources adds the resources times replicas to the total.
+func addResources(total, resources corev1.ResourceList, replicas int32) {
+	for name, quantity := range resources {
+		sum := total[name]
+		for i := int32(0); i < replicas; i++ {
+			sum.Add(quantity)
+		}
+		total[name] = sum
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestFootprint(t *testing.T) {
+	container := func(name, cpu, memory string) interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"cpu": cpu, "memory": memory},
+				"limits":   map[string]interface{}{"memory": "1Gi"},
+			},
+		}
+	}
+	templates := []*unstructured.Unstructured{
+		{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "manager"},
+			"spec": map[string]interface{}{
+				"replicas": int64(2),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"initContainers": []interface{}{container("init", "1", "1Gi")},
+						"containers": []interface{}{
+							container("manager", "100m", "256Mi"),
+							container("proxy", "10m", "32Mi"),
+						},
+					},
+				},
+			},
+		}},
+		{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "webhook"},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{container("webhook", "50m", "128Mi")},
+					},
+				},
+			},
+		}},
+		{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   map[string]interface{}{"name": "webhook"},
+		}},
+	}
+
+	footprint, err := Footprint("test", templates)
+	if err != nil {
+		t.Fatalf("Footprint() returned error: %v", err)
+	}
+	if footprint.Name != "test" || footprint.Deployments != 2 || footprint.Replicas != 3 ||
+		footprint.Containers != 5 {
+		t.Errorf("Footprint() = %s with %d deployments, %d replicas and %d containers, want test with 2, 3 and 5",
+			footprint.Name, footprint.Deployments, footprint.Replicas, footprint.Containers)
+	}
+	if got := footprint.Requests.Cpu().String(); got != "270m" {
+		t.Errorf("Footprint() cpu requests = %s, want 270m", got)
+	}
+	if got := footprint.Requests.Memory().String(); got != "704Mi" {
+		t.Errorf("Footprint() memory requests = %s, want 704Mi", got)
+	}
+	if got := footprint.Limits.Memory().String(); got != "5Gi" {
+		t.Errorf("Footprint() memory limits = %s, want 5Gi", got)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"helm.sh/helm/v3/pkg/chart"
+	loader "helm.sh/helm/v3/pkg/chart/loader"
+)
+
+var (
+	templateFSMu sync.RWMutex
+	// templateFS holds the charts, CRDs and add-on templates, with paths relative to the root of the repository
+	templateFS fs.FS = os.DirFS(".")
+)
+
+// SetTemplateFS sets the filesystem the templates are read from. The operator reads the templates
+// embedded in its binary; by default they are read from the working directory.
+func SetTemplateFS(fsys fs.FS) {
+	templateFSMu.Lock()
+	defer templateFSMu.Unlock()
+	templateFS = fsys
+	resetOverrideKeys()
+}
+
+// TemplateFS returns the filesystem the templates are read from.
+func TemplateFS() fs.FS {
+	templateFSMu.RLock()
+	defer templateFSMu.RUnlock()
+	return templateFS
+}
+
+// loadChart loads the chart in the directory chartPath of the filesystem.
+func loadChart(fsys fs.FS, chartPath string) (*chart.Chart, error) {
+	chartPath = path.Clean(chartPath)
+	files := []*loader.BufferedFile{}
+	err := fs.WalkDir(fsys, chartPath, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		files = append(files, &loader.BufferedFile{
+			Name: strings.TrimPrefix(name, chartPath+"/"),
+			Data: data,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadFiles(files)
+}
@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"testing"
+	"testing/fstest"
+
+	backplane "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTemplateFS(t *testing.T) {
+	useTemplateFS(t, fstest.MapFS{
+		"charts/test/Chart.yaml": {Data: []byte("apiVersion: v2\nname: test\nversion: 0.1.0\n")},
+		"charts/test/templates/configmap.yaml": {Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  namespace: '{{ .Values.global.namespace }}'
+`)},
+		"crds/test/tests.example.com.yaml": {Data: []byte(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: tests.example.com
+`)},
+		"crds/skipped/skipped.example.com.yaml": {Data: []byte(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: skipped.example.com
+`)},
+	})
+
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce"},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+
+	templates, errs := RenderChart("charts/test", mce, map[string]string{}, map[string]string{})
+	if len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors: %v", errs)
+	}
+	if len(templates) != 1 || templates[0].GetNamespace() != "test-ns" {
+		t.Fatalf("expected the ConfigMap of the chart to be rendered in test-ns, got %v", templates)
+	}
+	if data := templates[0].Object["data"].(map[string]interface{}); data["namespace"] != "test-ns" {
+		t.Errorf("expected the chart to be rendered with the values of the MultiClusterEngine, got %v", data)
+	}
+
+	if templates, errs := RenderCharts("charts", mce, map[string]string{}, map[string]string{}); len(errs) > 0 ||
+		len(templates) != 1 {
+		t.Errorf("expected the charts directory to render 1 template, got %d: %v", len(templates), errs)
+	}
+
+	crds, errs := RenderCRDs("crds", mce, []string{"skipped"})
+	if len(errs) > 0 {
+		t.Fatalf("RenderCRDs() returned errors: %v", errs)
+	}
+	if len(crds) != 1 || crds[0].GetName() != "tests.example.com" {
+		t.Errorf("expected only the CRD tests.example.com to be rendered, got %v", crds)
+	}
+}
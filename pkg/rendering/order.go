@@ -0,0 +1,94 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+/*
+installOrder is the order in which resources are applied, by kind, following the install order of
+Helm: namespaces first, then the resources other resources depend on, the workloads, the webhook
+configurations and finally the custom resources, whose kinds are not listed here.
+*/
+var installOrder = []string{
+	"PriorityClass",
+	"Namespace",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"CustomResourceDefinition",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"IngressClass",
+	"Ingress",
+	"Route",
+	"APIService",
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
+}
+
+var installRank = func() map[string]int {
+	rank := map[string]int{}
+	for i, kind := range installOrder {
+		rank[kind] = i
+	}
+	return rank
+}()
+
+// installsBefore reports whether a is applied before b: by kind in install order, with the kinds not
+// listed last in alphabetical order, then by name and namespace.
+func installsBefore(a, b *unstructured.Unstructured) bool {
+	rankA, knownA := installRank[a.GetKind()]
+	rankB, knownB := installRank[b.GetKind()]
+	switch {
+	case knownA != knownB:
+		return knownA
+	case knownA && rankA != rankB:
+		return rankA < rankB
+	case !knownA && a.GetKind() != b.GetKind():
+		return a.GetKind() < b.GetKind()
+	case a.GetName() != b.GetName():
+		return a.GetName() < b.GetName()
+	case a.GetNamespace() != b.GetNamespace():
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetAPIVersion() < b.GetAPIVersion()
+}
+
+// SortForInstall sorts the templates in the order they are applied in, and returns them.
+func SortForInstall(templates []*unstructured.Unstructured) []*unstructured.Unstructured {
+	sort.SliceStable(templates, func(i, j int) bool {
+		return installsBefore(templates[i], templates[j])
+	})
+	return templates
+}
+
+// SortForUninstall sorts the templates in the order they are deleted in, the reverse of the install order,
+// and returns them.
+func SortForUninstall(templates []*unstructured.Unstructured) []*unstructured.Unstructured {
+	sort.SliceStable(templates, func(i, j int) bool {
+		return installsBefore(templates[j], templates[i])
+	})
+	return templates
+}
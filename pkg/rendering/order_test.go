@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSortForInstall(t *testing.T) {
+	template := func(kind, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetKind(kind)
+		u.SetName(name)
+		return u
+	}
+	order := func(templates []*unstructured.Unstructured) []string {
+		names := []string{}
+		for _, t := range templates {
+			names = append(names, t.GetKind()+"/"+t.GetName())
+		}
+		return names
+	}
+	templates := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			template("ClusterManagementAddOn", "addon"),
+			template("Deployment", "b"),
+			template("ValidatingWebhookConfiguration", "webhook"),
+			template("Deployment", "a"),
+			template("AddOnTemplate", "template"),
+			template("ServiceAccount", "sa"),
+			template("CustomResourceDefinition", "crd"),
+			template("ClusterRoleBinding", "binding"),
+			template("ClusterRole", "role"),
+			template("Namespace", "ns"),
+			template("Service", "svc"),
+			template("ConfigMap", "cm"),
+		}
+	}
+
+	want := []string{"Namespace/ns", "ServiceAccount/sa", "ConfigMap/cm", "CustomResourceDefinition/crd",
+		"ClusterRole/role", "ClusterRoleBinding/binding", "Service/svc", "Deployment/a", "Deployment/b",
+		"ValidatingWebhookConfiguration/webhook", "AddOnTemplate/template", "ClusterManagementAddOn/addon"}
+	got := order(SortForInstall(templates()))
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SortForInstall() = %v, want %v", got, want)
+		}
+	}
+
+	got = order(SortForUninstall(templates()))
+	for i := range want {
+		if got[i] != want[len(want)-1-i] {
+			t.Fatalf("SortForUninstall() = %v, want the reverse of %v", got, want)
+		}
+	}
+}
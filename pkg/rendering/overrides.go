@@ -0,0 +1,128 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// templateOverrideKeys holds the template override keys the charts read and the prefixes of the charts.
+type templateOverrideKeys struct {
+	keys     map[string]bool
+	prefixes []string
+}
+
+var (
+	overrideKeysMu sync.Mutex
+	// overrideKeys caches the keys of the charts under each charts directory of the template filesystem
+	overrideKeys = map[string]templateOverrideKeys{}
+)
+
+// resetOverrideKeys drops the cached keys, as they are read from the template filesystem.
+func resetOverrideKeys() {
+	overrideKeysMu.Lock()
+	defer overrideKeysMu.Unlock()
+	overrideKeys = map[string]templateOverrideKeys{}
+}
+
+/*
+chartOverrideKeys returns the template override keys read by the charts under the directory root, and the
+prefix of each chart, such as console_mce_ for the console-mce chart.
+*/
+func chartOverrideKeys(fsys fs.FS, root string) (templateOverrideKeys, error) {
+	overrideKeysMu.Lock()
+	defer overrideKeysMu.Unlock()
+	if cached, ok := overrideKeys[root]; ok {
+		return cached, nil
+	}
+
+	result := templateOverrideKeys{keys: map[string]bool{}}
+	prefixes := map[string]bool{}
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if path.Base(name) == "Chart.yaml" {
+			metadata := struct {
+				Name string `json:"name"`
+			}{}
+			if err := yaml.Unmarshal(data, &metadata); err != nil {
+				return fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			prefixes[chartOverridePrefix(metadata.Name)] = true
+			return nil
+		}
+		if !strings.Contains(name, "/templates/") {
+			return nil
+		}
+		for _, match := range overridePattern.FindAllStringSubmatch(string(data), -1) {
+			if match[1] == "templateOverrides" {
+				result.keys[match[2]] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	for prefix := range prefixes {
+		result.prefixes = append(result.prefixes, prefix)
+	}
+	sort.Strings(result.prefixes)
+	overrideKeys[root] = result
+	return result, nil
+}
+
+// chartOverridePrefix returns the prefix of the template override keys of a chart.
+func chartOverridePrefix(chartName string) string {
+	return strings.ReplaceAll(chartName, "-", "_") + "_"
+}
+
+/*
+validateTemplateOverrides rejects the template overrides with the prefix of the chart that no chart reads, so
+that a mistyped override, or one aimed at a chart that reads no overrides, is reported on the component it
+names instead of being ignored. A key belongs to the chart with the longest prefix it starts with, as the
+prefix of a chart, such as cluster_api_, may be the start of the prefix of another chart.
+*/
+func validateTemplateOverrides(fsys fs.FS, chartPath, chartName string, overrides map[string]string) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	known, err := chartOverrideKeys(fsys, path.Dir(path.Dir(path.Clean(chartPath))))
+	if err != nil {
+		return err
+	}
+
+	prefix := chartOverridePrefix(chartName)
+	unknown := []string{}
+	for key := range overrides {
+		if known.keys[key] || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		owner := prefix
+		for _, p := range known.prefixes {
+			if strings.HasPrefix(key, p) && len(p) > len(owner) {
+				owner = p
+			}
+		}
+		if owner == prefix {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("template overrides are not read by any chart: %s", strings.Join(unknown, ", "))
+}
@@ -0,0 +1,83 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	backplane "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateTemplateOverrides(t *testing.T) {
+	fsys := os.DirFS("../../")
+	tests := []struct {
+		name      string
+		chartPath string
+		chartName string
+		overrides map[string]string
+		unknown   string
+	}{
+		{
+			name:      "read by the chart",
+			chartPath: "pkg/templates/charts/toggle/console-mce",
+			chartName: "console-mce",
+			overrides: map[string]string{"console_mce_deployment_container_memory_limit": "1Gi"},
+		},
+		{
+			name:      "mistyped under a chart that reads overrides",
+			chartPath: "pkg/templates/charts/toggle/console-mce",
+			chartName: "console-mce",
+			overrides: map[string]string{"console_mce_deployment_container_memroy_limit": "1Gi"},
+			unknown:   "console_mce_deployment_container_memroy_limit",
+		},
+		{
+			name:      "aimed at a chart that reads no overrides",
+			chartPath: "pkg/templates/charts/toggle/discovery-operator",
+			chartName: "discovery-operator",
+			overrides: map[string]string{"discovery_operator_deployment_container_memory_limit": "1Gi"},
+			unknown:   "discovery_operator_deployment_container_memory_limit",
+		},
+		{
+			name:      "with the prefix of another chart",
+			chartPath: "pkg/templates/charts/toggle/discovery-operator",
+			chartName: "discovery-operator",
+			overrides: map[string]string{"console_mce_deployment_container_memroy_limit": "1Gi"},
+		},
+		{
+			name:      "with the prefix of a longer chart prefix",
+			chartPath: "pkg/templates/charts/toggle/cluster-api",
+			chartName: "cluster-api",
+			overrides: map[string]string{"cluster_api_provider_aws_deployment_replicas": "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTemplateOverrides(fsys, tt.chartPath, tt.chartName, tt.overrides)
+			if tt.unknown == "" && err != nil {
+				t.Errorf("validateTemplateOverrides() returned error: %v", err)
+			}
+			if tt.unknown != "" && (err == nil || !strings.Contains(err.Error(), tt.unknown)) {
+				t.Errorf("expected %s to be reported, got %v", tt.unknown, err)
+			}
+		})
+	}
+}
+
+func TestRenderUnknownTemplateOverride(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce"},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "default"},
+	}
+	_, errs := RenderChart("pkg/templates/charts/toggle/managed-serviceaccount", mce, map[string]string{},
+		map[string]string{"managed_serviceaccount_deployment_replicas": "2"})
+	var valuesErr *ValuesError
+	if len(errs) != 1 || !errors.As(errs[0], &valuesErr) || valuesErr.Chart != "managed-serviceaccount" {
+		t.Errorf("expected the unknown override to fail the chart with a values error, got %v", errs)
+	}
+}
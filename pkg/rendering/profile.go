@@ -0,0 +1,183 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// profileResources are the resource requests and limits of a profile, by Deployment and container name.
+type profileResources map[string]map[string]corev1.ResourceRequirements
+
+/*
+resourceProfiles are the resource requests and limits of the containers of the Deployments of the charts
+for each resource profile. Every container is sized, so that a profile covers the whole engine. CPU is never
+limited so that the controllers are not throttled while they catch up after an outage; the CPU limits some
+charts set are kept.
+*/
+var resourceProfiles = map[v1.ResourceProfile]profileResources{
+	v1.ResourceProfileSmall: {
+		"azureserviceoperator-controller-manager": {"manager": sized("100m", "128Mi", "512Mi")},
+		"capa-controller-manager":                 {"manager": sized("10m", "64Mi", "256Mi")},
+		"capi-controller-manager":                 {"manager": sized("10m", "64Mi", "256Mi")},
+		"capoa-bootstrap-controller-manager":      {"manager": sized("10m", "32Mi", "128Mi")},
+		"capoa-controlplane-controller-manager":   {"manager": sized("10m", "32Mi", "128Mi")},
+		"capz-controller-manager":                 {"manager": sized("10m", "64Mi", "256Mi")},
+		"cluster-curator-controller":              {"cluster-curator-controller": sized("3m", "16Mi", "128Mi")},
+		"cluster-image-set-controller":            {"cluster-image-set-controller": sized("3m", "16Mi", "128Mi")},
+		"cluster-manager":                         {"registration-operator": sized("2m", "16Mi", "256Mi")},
+		"cluster-permission":                      {"cluster-permission": sized("10m", "32Mi", "256Mi")},
+		"cluster-proxy-addon-manager":             {"manager": sized("10m", "64Mi", "256Mi")},
+		"cluster-proxy-addon-user": {
+			"controllers": sized("5m", "32Mi", "128Mi"),
+			"user-server": sized("10m", "128Mi", "512Mi"),
+		},
+		"clusterclaims-controller": {
+			"clusterclaims-controller":       sized("3m", "32Mi", "128Mi"),
+			"clusterpools-delete-controller": sized("3m", "32Mi", "128Mi"),
+		},
+		"clusterlifecycle-state-metrics-v2": {"clusterlifecycle-state-metrics": sized("10m", "16Mi", "128Mi")},
+		"console-mce-console":               {"console": sized("3m", "32Mi", "128Mi")},
+		"discovery-operator":                {"discovery-operator": sized("50m", "64Mi", "256Mi")},
+		"hive-operator":                     {"hive-operator": sized("50m", "128Mi", "1Gi")},
+		"hypershift-addon-manager":          {"hypershift-addon-manager": sized("2m", "2Mi", "256Mi")},
+		"image-based-install-operator": {
+			"manager": sized("10m", "128Mi", "512Mi"),
+			"server":  sized("10m", "32Mi", "256Mi"),
+		},
+		"infrastructure-operator":             {"manager": sized("50m", "128Mi", "512Mi")},
+		"maestro":                             {"service": sized("50m", "128Mi", "512Mi")},
+		"managedcluster-import-controller-v2": {"managedcluster-import-controller": sized("25m", "64Mi", "512Mi")},
+		"mce-capi-webhook-config":             {"manager": sized("5m", "32Mi", "128Mi")},
+		"mce-capm3-controller-manager":        {"manager": sized("10m", "64Mi", "256Mi")},
+		"ocm-controller":                      {"ocm-controller": sized("50m", "128Mi", "1Gi")},
+		"ocm-proxyserver":                     {"ocm-proxyserver": sized("50m", "128Mi", "512Mi")},
+		"ocm-webhook":                         {"ocm-webhook": sized("25m", "64Mi", "256Mi")},
+		"provider-credential-controller": {
+			"old-provider-connection":        sized("3m", "32Mi", "128Mi"),
+			"provider-credential-controller": sized("3m", "32Mi", "128Mi"),
+		},
+	},
+	// Medium is the size the charts are written for, so it keeps their requests and limits.
+	v1.ResourceProfileMedium: nil,
+	v1.ResourceProfileLarge: {
+		"azureserviceoperator-controller-manager": {"manager": sized("200m", "512Mi", "2Gi")},
+		"capa-controller-manager":                 {"manager": sized("100m", "256Mi", "1Gi")},
+		"capi-controller-manager":                 {"manager": sized("100m", "256Mi", "1Gi")},
+		"capoa-bootstrap-controller-manager":      {"manager": sized("50m", "128Mi", "512Mi")},
+		"capoa-controlplane-controller-manager":   {"manager": sized("50m", "128Mi", "512Mi")},
+		"capz-controller-manager":                 {"manager": sized("100m", "256Mi", "1Gi")},
+		"cluster-curator-controller":              {"cluster-curator-controller": sized("10m", "64Mi", "512Mi")},
+		"cluster-image-set-controller":            {"cluster-image-set-controller": sized("10m", "64Mi", "256Mi")},
+		"cluster-manager":                         {"registration-operator": sized("10m", "64Mi", "512Mi")},
+		"cluster-permission":                      {"cluster-permission": sized("50m", "128Mi", "512Mi")},
+		"cluster-proxy-addon-manager":             {"manager": sized("50m", "256Mi", "1Gi")},
+		"cluster-proxy-addon-user": {
+			"controllers": sized("25m", "128Mi", "512Mi"),
+			"user-server": sized("50m", "512Mi", "2Gi"),
+		},
+		"clusterclaims-controller": {
+			"clusterclaims-controller":       sized("10m", "128Mi", "512Mi"),
+			"clusterpools-delete-controller": sized("10m", "128Mi", "512Mi"),
+		},
+		"clusterlifecycle-state-metrics-v2": {"clusterlifecycle-state-metrics": sized("50m", "128Mi", "512Mi")},
+		"console-mce-console":               {"console": sized("10m", "64Mi", "256Mi")},
+		"discovery-operator":                {"discovery-operator": sized("100m", "256Mi", "1Gi")},
+		"hive-operator":                     {"hive-operator": sized("200m", "512Mi", "4Gi")},
+		"hypershift-addon-manager":          {"hypershift-addon-manager": sized("10m", "64Mi", "1Gi")},
+		"image-based-install-operator": {
+			"manager": sized("50m", "512Mi", "2Gi"),
+			"server":  sized("50m", "128Mi", "512Mi"),
+		},
+		"infrastructure-operator":             {"manager": sized("200m", "512Mi", "2Gi")},
+		"maestro":                             {"service": sized("200m", "512Mi", "2Gi")},
+		"managedcluster-import-controller-v2": {"managedcluster-import-controller": sized("100m", "256Mi", "2Gi")},
+		"mce-capi-webhook-config":             {"manager": sized("25m", "64Mi", "256Mi")},
+		"mce-capm3-controller-manager":        {"manager": sized("100m", "256Mi", "1Gi")},
+		"ocm-controller":                      {"ocm-controller": sized("200m", "512Mi", "4Gi")},
+		"ocm-proxyserver":                     {"ocm-proxyserver": sized("200m", "512Mi", "2Gi")},
+		"ocm-webhook":                         {"ocm-webhook": sized("100m", "256Mi", "1Gi")},
+		"provider-credential-controller": {
+			"old-provider-connection":        sized("10m", "64Mi", "256Mi"),
+			"provider-credential-controller": sized("10m", "128Mi", "512Mi"),
+		},
+	},
+}
+
+// sized returns the resource requirements of a container requesting cpu and memory, with its memory limited.
+func sized(cpu, memory, memoryLimit string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse(memoryLimit),
+		},
+	}
+}
+
+/*
+applyResourceProfile sets the resource requests and limits of the profile on the containers of the
+Deployments of the templates of the chart. Requests and limits set through the template overrides of the
+chart, such as console_mce_deployment_container_memory_limit, keep their value.
+*/
+func applyResourceProfile(templates []*unstructured.Unstructured, profile v1.ResourceProfile, chartName string,
+	templateOverrides map[string]string) error {
+	deployments := resourceProfiles[profile]
+	if len(deployments) == 0 {
+		return nil
+	}
+	for _, template := range templates {
+		if template.GetKind() != "Deployment" {
+			continue
+		}
+		containerResources, ok := deployments[template.GetName()]
+		if !ok {
+			continue
+		}
+
+		containers, found, err := unstructured.NestedSlice(template.Object, "spec", "template", "spec", "containers")
+		if err != nil || !found {
+			return fmt.Errorf("failed to read the containers of Deployment %s: %v", template.GetName(), err)
+		}
+		for i, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(container, "name")
+			requirements, ok := containerResources[name]
+			if !ok {
+				continue
+			}
+			for field, list := range map[string]corev1.ResourceList{
+				"requests": requirements.Requests, "limits": requirements.Limits} {
+				for resourceName, quantity := range list {
+					override := fmt.Sprintf("%s_deployment_container_%s_%s", strings.ReplaceAll(chartName, "-", "_"),
+						resourceName, strings.TrimSuffix(field, "s"))
+					if _, ok := templateOverrides[override]; ok {
+						continue
+					}
+					if err := unstructured.SetNestedField(container, quantity.String(),
+						"resources", field, string(resourceName)); err != nil {
+						return fmt.Errorf("failed to size container %s of Deployment %s: %w", name,
+							template.GetName(), err)
+					}
+				}
+			}
+			containers[i] = container
+		}
+		if err := unstructured.SetNestedSlice(template.Object, containers,
+			"spec", "template", "spec", "containers"); err != nil {
+			return fmt.Errorf("failed to set the containers of Deployment %s: %w", template.GetName(), err)
+		}
+	}
+	return nil
+}
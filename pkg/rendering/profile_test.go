@@ -0,0 +1,179 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"os"
+	"testing"
+
+	v1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRenderResourceProfile(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+
+	tests := []struct {
+		profile     v1.ResourceProfile
+		wantRequest string
+		wantLimit   string
+	}{
+		{profile: "", wantRequest: "100Mi"},
+		{profile: v1.ResourceProfileSmall, wantRequest: "64Mi", wantLimit: "256Mi"},
+		{profile: v1.ResourceProfileMedium, wantRequest: "100Mi"},
+		{profile: v1.ResourceProfileLarge, wantRequest: "256Mi", wantLimit: "1Gi"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			mce := &v1.MultiClusterEngine{
+				ObjectMeta: metav1.ObjectMeta{Name: "testBackplaneConfig"},
+				Spec: v1.MultiClusterEngineSpec{
+					TargetNamespace: "multicluster-engine",
+					ResourceProfile: tt.profile,
+				},
+			}
+			templates, errs := RenderChart("pkg/templates/charts/toggle/discovery-operator", mce,
+				map[string]string{"discovery_operator": "quay.io/test/discovery:test"}, map[string]string{})
+			if len(errs) > 0 {
+				t.Fatalf("RenderChart() returned errors: %v", errs)
+			}
+
+			var resources *corev1.ResourceRequirements
+			for _, template := range templates {
+				if template.GetKind() != "Deployment" {
+					continue
+				}
+				deployment := &appsv1.Deployment{}
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+					t.Fatalf("failed to convert the Deployment: %v", err)
+				}
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					if container.Name == "discovery-operator" {
+						resources = &container.Resources
+					}
+				}
+			}
+			if resources == nil {
+				t.Fatalf("expected the discovery-operator container to be rendered")
+			}
+
+			if got := resources.Requests.Memory().String(); got != tt.wantRequest {
+				t.Errorf("expected a memory request of %s, got %s", tt.wantRequest, got)
+			}
+			if got := resources.Requests.Cpu().String(); tt.profile != v1.ResourceProfileSmall && got != "100m" {
+				t.Errorf("expected the cpu request of the chart, got %s", got)
+			}
+			limit, found := resources.Limits[corev1.ResourceMemory]
+			if tt.wantLimit == "" && found {
+				t.Errorf("expected no memory limit, got %s", limit.String())
+			} else if tt.wantLimit != "" && limit.String() != tt.wantLimit {
+				t.Errorf("expected a memory limit of %s, got %s", tt.wantLimit, limit.String())
+			}
+			if _, found := resources.Limits[corev1.ResourceCPU]; found {
+				t.Errorf("expected no cpu limit")
+			}
+		})
+	}
+}
+
+func TestResourceProfiles(t *testing.T) {
+	for profile, deployments := range resourceProfiles {
+		for deployment, containers := range deployments {
+			for container, resources := range containers {
+				for name, request := range resources.Requests {
+					limit, found := resources.Limits[name]
+					if found && request.Cmp(limit) > 0 {
+						t.Errorf("expected the %s request of %s/%s in profile %s not to exceed its limit",
+							name, deployment, container, profile)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestResourceProfilesCoverCharts(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+
+	images := map[string]string{}
+	for _, v := range utils.GetTestImages() {
+		images[v] = "quay.io/test/test:Test"
+	}
+	for profile, deployments := range resourceProfiles {
+		if deployments == nil {
+			continue
+		}
+		mce := &v1.MultiClusterEngine{
+			ObjectMeta: metav1.ObjectMeta{Name: "testBackplaneConfig"},
+			Spec:       v1.MultiClusterEngineSpec{TargetNamespace: "multicluster-engine", ResourceProfile: profile},
+		}
+		for _, dir := range []string{chartsDir, AlwaysChartsDir, "pkg/templates/charts/hosting"} {
+			templates, errs := RenderCharts(dir, mce, images, map[string]string{})
+			if len(errs) > 0 {
+				t.Fatalf("RenderCharts(%s) returned errors: %v", dir, errs)
+			}
+			for _, template := range templates {
+				if template.GetKind() != "Deployment" {
+					continue
+				}
+				deployment := &appsv1.Deployment{}
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+					t.Fatalf("failed to convert Deployment %s: %v", template.GetName(), err)
+				}
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					if _, ok := deployments[deployment.Name][container.Name]; !ok {
+						t.Errorf("expected profile %s to size container %s of Deployment %s", profile,
+							container.Name, deployment.Name)
+					}
+					for name, request := range container.Resources.Requests {
+						if limit, found := container.Resources.Limits[name]; found && request.Cmp(limit) > 0 {
+							t.Errorf("expected the %s request of %s/%s in profile %s not to exceed the limit of the chart",
+								name, deployment.Name, container.Name, profile)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRenderResourceProfileTemplateOverrides(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+
+	mce := &v1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "testBackplaneConfig"},
+		Spec: v1.MultiClusterEngineSpec{
+			TargetNamespace: "multicluster-engine",
+			ResourceProfile: v1.ResourceProfileLarge,
+		},
+	}
+	templates, errs := RenderChart("pkg/templates/charts/toggle/console-mce", mce,
+		map[string]string{"console_mce": "quay.io/test/console:test"},
+		map[string]string{"console_mce_deployment_container_memory_request": "100Mi"})
+	if len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors: %v", errs)
+	}
+	for _, template := range templates {
+		if template.GetKind() != "Deployment" {
+			continue
+		}
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, deployment); err != nil {
+			t.Fatalf("failed to convert the Deployment: %v", err)
+		}
+		resources := deployment.Spec.Template.Spec.Containers[0].Resources
+		if got := resources.Requests.Memory().String(); got != "100Mi" {
+			t.Errorf("expected the template override to keep the memory request, got %s", got)
+		}
+		if got := resources.Requests.Cpu().String(); got != "10m" {
+			t.Errorf("expected the profile to size the cpu request, got %s", got)
+		}
+	}
+}
@@ -19,20 +19,20 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
-	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
 
-	loader "helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 
 	v1 "github.com/stolostron/backplane-operator/api/v1"
 	"github.com/stolostron/backplane-operator/pkg/utils"
 	"helm.sh/helm/v3/pkg/engine"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -242,6 +242,22 @@ func (u *Toleration) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// ValuesError is returned when the values a chart is rendered with, such as the template overrides, do
+// not match the values.schema.json of the chart.
+type ValuesError struct {
+	Chart string
+	err   error
+}
+
+func (e *ValuesError) Error() string {
+	details := strings.TrimPrefix(e.err.Error(), e.Chart+":\n")
+	return fmt.Sprintf("invalid values for chart %s: %s", e.Chart, strings.Join(strings.Fields(details), " "))
+}
+
+func (e *ValuesError) Unwrap() error {
+	return e.err
+}
+
 func (val *Values) ToValues() (chartutil.Values, error) {
 	inrec, err := json.Marshal(val)
 	if err != nil {
@@ -259,12 +275,11 @@ func RenderCRDs(crdDir string, backplaneConfig *v1.MultiClusterEngine, skipDirs
 	var crds []*unstructured.Unstructured
 	errs := []error{}
 
-	if val, ok := os.LookupEnv("DIRECTORY_OVERRIDE"); ok {
-		crdDir = path.Join(val, crdDir)
-	}
+	templates := TemplateFS()
+	crdDir = path.Clean(crdDir)
 
 	// Read CRD files
-	err := filepath.Walk(crdDir, func(filePath string, info os.FileInfo, err error) error {
+	err := fs.WalkDir(templates, crdDir, func(filePath string, info fs.DirEntry, err error) error {
 		if err != nil {
 			fmt.Println(err.Error())
 			return err
@@ -278,13 +293,10 @@ func RenderCRDs(crdDir string, backplaneConfig *v1.MultiClusterEngine, skipDirs
 		// Check if this file is in a directory that should be skipped
 		for _, skipDir := range skipDirs {
 			// Get the relative path from crdDir to the file
-			relPath, err := filepath.Rel(crdDir, filePath)
-			if err != nil {
-				continue
-			}
+			relPath := strings.TrimPrefix(filePath, crdDir+"/")
 
 			// Get the immediate parent directory of the file
-			fileDir := filepath.Dir(relPath)
+			fileDir := path.Dir(relPath)
 
 			// Check if the file's directory matches the skip directory
 			if fileDir == skipDir {
@@ -293,7 +305,7 @@ func RenderCRDs(crdDir string, backplaneConfig *v1.MultiClusterEngine, skipDirs
 			}
 		}
 
-		bytesFile, e := os.ReadFile(filepath.Clean(filePath)) // #nosec G304,G122 -- filePath from filepath.Walk within operator's own template directory
+		bytesFile, e := fs.ReadFile(templates, filePath)
 		if e != nil {
 			errs = append(errs, fmt.Errorf("%s - error reading file: %v", info.Name(), err.Error()))
 		}
@@ -330,18 +342,15 @@ func RenderCharts(chartDir string, backplaneConfig *v1.MultiClusterEngine, image
 	var templates []*unstructured.Unstructured
 	errs := []error{}
 
-	if val, ok := os.LookupEnv("DIRECTORY_OVERRIDE"); ok {
-		chartDir = path.Join(val, chartDir)
-	}
-
-	charts, err := os.ReadDir(chartDir)
+	charts, err := fs.ReadDir(TemplateFS(), path.Clean(chartDir))
 	if err != nil {
 		errs = append(errs, err)
 	}
 
+	values := renderValues(backplaneConfig, images, templateOverrides)
 	for _, chart := range charts {
-		chartPath := filepath.Join(chartDir, chart.Name())
-		chartTemplates, errs := renderTemplates(chartPath, backplaneConfig, images, templateOverrides)
+		chartPath := path.Join(chartDir, chart.Name())
+		chartTemplates, errs := renderTemplates(chartPath, backplaneConfig, values)
 
 		if len(errs) > 0 {
 			for _, err := range errs {
@@ -351,19 +360,15 @@ func RenderCharts(chartDir string, backplaneConfig *v1.MultiClusterEngine, image
 		}
 		templates = append(templates, chartTemplates...)
 	}
-	return templates, nil
+	return SortForInstall(templates), nil
 }
 
 func RenderChart(chartPath string, backplaneConfig *v1.MultiClusterEngine, images map[string]string,
 	templates map[string]string) ([]*unstructured.Unstructured, []error) {
 
 	log := log.Log.WithName("reconcile")
-	errs := []error{}
-	if val, ok := os.LookupEnv("DIRECTORY_OVERRIDE"); ok {
-		chartPath = path.Join(val, chartPath)
-	}
-
-	chartTemplates, errs := renderTemplates(chartPath, backplaneConfig, images, templates)
+	chartTemplates, errs := renderTemplates(chartPath, backplaneConfig,
+		renderValues(backplaneConfig, images, templates))
 	if len(errs) > 0 {
 		for _, err := range errs {
 			log.Info(err.Error())
@@ -383,21 +388,27 @@ func RenderChartWithNamespace(chartPath string, backplaneConfig *v1.MultiCluster
 	return RenderChart(chartPath, mce, images, templates)
 }
 
-func renderTemplates(chartPath string, backplaneConfig *v1.MultiClusterEngine, images map[string]string,
-	templateOverrides map[string]string) ([]*unstructured.Unstructured, []error) {
+// renderValues returns the values the charts of the MultiClusterEngine are rendered with.
+func renderValues(backplaneConfig *v1.MultiClusterEngine, images map[string]string,
+	templateOverrides map[string]string) *Values {
+	values := &Values{}
+	injectValuesOverrides(values, backplaneConfig, images, templateOverrides)
+	return values
+}
+
+func renderTemplates(chartPath string, backplaneConfig *v1.MultiClusterEngine,
+	valuesYaml *Values) ([]*unstructured.Unstructured, []error) {
 
 	log := log.Log.WithName("reconcile")
 	var templates []*unstructured.Unstructured
 	errs := []error{}
 
-	chart, err := loader.Load(chartPath)
+	chart, err := loadChart(TemplateFS(), chartPath)
 	if err != nil {
 		log.Info(fmt.Sprintf("error loading chart from path: %s", chartPath))
 		return nil, append(errs, err)
 	}
 
-	valuesYaml := &Values{}
-	injectValuesOverrides(valuesYaml, backplaneConfig, images, templateOverrides)
 	helmEngine := engine.Engine{
 		Strict:   true,
 		LintMode: false,
@@ -409,6 +420,16 @@ func renderTemplates(chartPath string, backplaneConfig *v1.MultiClusterEngine, i
 		return nil, append(errs, err)
 	}
 
+	if err := chartutil.ValidateAgainstSchema(chart, vals.AsMap()); err != nil {
+		log.Info(fmt.Sprintf("invalid values for chart: %s", chart.Name()))
+		return nil, append(errs, &ValuesError{Chart: chart.Name(), err: err})
+	}
+	if err := validateTemplateOverrides(TemplateFS(), chartPath, chart.Name(),
+		valuesYaml.Global.TemplateOverrides); err != nil {
+		log.Info(fmt.Sprintf("invalid template overrides for chart: %s", chart.Name()))
+		return nil, append(errs, &ValuesError{Chart: chart.Name(), err: err})
+	}
+
 	rawTemplates, err := helmEngine.Render(chart, chartutil.Values{"Values": vals.AsMap()})
 	if err != nil {
 		log.Info(fmt.Sprintf("error rendering chart: %s", chart.Name()))
@@ -432,16 +453,37 @@ func renderTemplates(chartPath string, backplaneConfig *v1.MultiClusterEngine, i
 		}
 		utils.AddBackplaneConfigLabels(unstructured, backplaneConfig.Name)
 
-		// Add namespace to namespaced resources
-		switch kind {
-		case "Deployment", "ServiceAccount", "Role", "RoleBinding", "Service", "ConfigMap", "Route", "NetworkPolicy":
-			unstructured.SetNamespace(backplaneConfig.Spec.TargetNamespace)
+		// Add namespace to the namespaced resources the chart did not scope. Kinds that are not known, such as
+		// those of CRDs not installed yet, are left as they are for the apply path to report the missing CRD.
+		if unstructured.GetNamespace() == "" {
+			namespaced, err := isNamespaced(unstructured)
+			if meta.IsNoMatchError(err) {
+				log.Info("Not scoping resource of a kind the cluster does not know", "Kind", unstructured.GetKind(),
+					"Name", unstructured.GetName())
+			} else if err != nil {
+				return nil, append(errs, fmt.Errorf("error scoping file %s of chart %s: %w", fileName, chart.Name(), err))
+			}
+			if namespaced {
+				unstructured.SetNamespace(backplaneConfig.Spec.TargetNamespace)
+			}
 		}
 
 		templates = append(templates, unstructured)
 	}
 
-	return templates, errs
+	if backplaneConfig.Spec.AvailabilityConfig == v1.HAHigh {
+		templates, err = applyHighAvailability(templates, backplaneConfig)
+		if err != nil {
+			return nil, append(errs, fmt.Errorf("error making chart %s highly available: %w", chart.Name(), err))
+		}
+	}
+
+	if err := applyResourceProfile(templates, backplaneConfig.Spec.ResourceProfile, chart.Name(),
+		valuesYaml.Global.TemplateOverrides); err != nil {
+		return nil, append(errs, fmt.Errorf("error sizing chart %s: %w", chart.Name(), err))
+	}
+
+	return SortForInstall(templates), errs
 }
 
 func injectValuesOverrides(values *Values, backplaneConfig *v1.MultiClusterEngine, images map[string]string,
@@ -465,7 +507,7 @@ func injectValuesOverrides(values *Values, backplaneConfig *v1.MultiClusterEngin
 	if !values.Global.DeployOnOCP {
 		servingCertCABundle, err := utils.GetServingCertCABundle()
 		if err != nil {
-			fmt.Printf("error getting serving cert ca bundle: %s\n", err)
+			log.Log.WithName("reconcile").Info("error getting serving cert ca bundle", "error", err.Error())
 		} else {
 			values.Global.ServingCertCABundle = base64.StdEncoding.EncodeToString([]byte(servingCertCABundle))
 		}
@@ -6,6 +6,7 @@ package renderer
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"reflect"
 	"strings"
@@ -30,13 +31,20 @@ import (
 const (
 	chartsDir  = "pkg/templates/charts/toggle"
 	chartsPath = "pkg/templates/charts/toggle/managed-serviceaccount"
-	crdsDir    = "../../hack/unit-test-crds"
+	crdsDir    = "hack/unit-test-crds"
 )
 
+// useTemplateFS reads the templates from fsys for the duration of the test.
+func useTemplateFS(t *testing.T, fsys fs.FS) {
+	t.Helper()
+	saved := TemplateFS()
+	SetTemplateFS(fsys)
+	t.Cleanup(func() { SetTemplateFS(saved) })
+}
+
 func TestRender(t *testing.T) {
 
-	os.Setenv("DIRECTORY_OVERRIDE", "../../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS("../../"))
 
 	availabilityList := []string{"clusterclaims-controller", "cluster-curator-controller", "managedcluster-import-controller-v2", "ocm-controller", "ocm-proxyserver", "ocm-webhook"}
 	backplaneNodeSelector := map[string]string{"select": "test", "select2": "test2"}
@@ -286,8 +294,7 @@ func TestRender(t *testing.T) {
 
 func TestNonOCPRender(t *testing.T) {
 
-	os.Setenv("DIRECTORY_OVERRIDE", "../../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS("../../"))
 	originalDeployOnOCP := utils.DeployOnOCP()
 	defer utils.SetDeployOnOCP(originalDeployOnOCP)
 	utils.SetDeployOnOCP(false)
@@ -538,6 +545,8 @@ func TestNonOCPRender(t *testing.T) {
 }
 
 func TestRenderCoreCRDs(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+
 	tests := []struct {
 		name   string
 		crdDir string
@@ -571,6 +580,8 @@ func TestRenderCoreCRDs(t *testing.T) {
 	}
 }
 func TestRenderCRDs(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+
 	testBackplane := &backplane.MultiClusterEngine{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "testBackplane",
@@ -619,6 +630,8 @@ func TestRenderCRDs(t *testing.T) {
 }
 
 func TestRenderCRDsSkipDirectories(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+
 	// Test that "cluster-api" skip doesn't affect "cluster-api-provider-aws"
 	tests := []struct {
 		name         string
@@ -628,7 +641,7 @@ func TestRenderCRDsSkipDirectories(t *testing.T) {
 	}{
 		{
 			name:     "Skip cluster-api but not cluster-api-provider-aws",
-			crdDir:   "../../test/unit-test-crds",
+			crdDir:   "test/unit-test-crds",
 			skipDirs: []string{"cluster-api"},
 			expectedCRDs: map[string]bool{
 				// CAPA CRDs should still be rendered
@@ -639,7 +652,7 @@ func TestRenderCRDsSkipDirectories(t *testing.T) {
 		},
 		{
 			name:     "Skip cluster-api-provider-aws but not cluster-api",
-			crdDir:   "../../test/unit-test-crds",
+			crdDir:   "test/unit-test-crds",
 			skipDirs: []string{"cluster-api-provider-aws"},
 			expectedCRDs: map[string]bool{
 				// CAPI CRDs should be rendered
@@ -678,8 +691,7 @@ func TestRenderCRDsSkipDirectories(t *testing.T) {
 }
 
 func TestNetworkPoliciesValueInjection(t *testing.T) {
-	os.Setenv("DIRECTORY_OVERRIDE", "../../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS("../../"))
 	os.Setenv("POD_NAMESPACE", "default")
 	defer os.Unsetenv("POD_NAMESPACE")
 	os.Setenv("ACM_HUB_OCP_VERSION", "4.12.0")
@@ -741,7 +753,7 @@ func TestNetworkPoliciesValueInjection(t *testing.T) {
 }
 
 func TestClusterLifecycleStateMetricsNetworkPolicies(t *testing.T) {
-	t.Setenv("DIRECTORY_OVERRIDE", "../../")
+	useTemplateFS(t, os.DirFS("../../"))
 	t.Setenv("POD_NAMESPACE", "default")
 	t.Setenv("ACM_HUB_OCP_VERSION", "4.12.0")
 
@@ -837,8 +849,7 @@ func TestClusterLifecycleStateMetricsNetworkPolicies(t *testing.T) {
 }
 
 func TestManagedServiceAccountNetworkPolicies(t *testing.T) {
-	os.Setenv("DIRECTORY_OVERRIDE", "../../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS("../../"))
 	os.Setenv("POD_NAMESPACE", "default")
 	defer os.Unsetenv("POD_NAMESPACE")
 	os.Setenv("ACM_HUB_OCP_VERSION", "4.12.0")
@@ -918,8 +929,7 @@ func TestManagedServiceAccountNetworkPolicies(t *testing.T) {
 }
 
 func TestServerFoundationNetworkPolicies(t *testing.T) {
-	os.Setenv("DIRECTORY_OVERRIDE", "../../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS("../../"))
 	os.Setenv("POD_NAMESPACE", "default")
 	defer os.Unsetenv("POD_NAMESPACE")
 	os.Setenv("ACM_HUB_OCP_VERSION", "4.12.0")
@@ -1377,8 +1387,7 @@ func TestServerFoundationNetworkPolicies(t *testing.T) {
 }
 
 func TestClusterManagerNetworkPolicies(t *testing.T) {
-	os.Setenv("DIRECTORY_OVERRIDE", "../../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS("../../"))
 	os.Setenv("POD_NAMESPACE", "default")
 	defer os.Unsetenv("POD_NAMESPACE")
 	os.Setenv("ACM_HUB_OCP_VERSION", "4.12.0")
@@ -1612,8 +1621,7 @@ func assertServerFoundationNetworkPolicy(t *testing.T, tmpl *unstructured.Unstru
 }
 
 func TestRenderChartInvalidPath(t *testing.T) {
-	os.Setenv("DIRECTORY_OVERRIDE", "../../")
-	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	useTemplateFS(t, os.DirFS("../../"))
 
 	mce := &backplane.MultiClusterEngine{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-mce"},
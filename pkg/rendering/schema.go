@@ -0,0 +1,243 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// quantityPattern matches a Kubernetes resource quantity, such as 512Mi or 100m.
+const quantityPattern = `^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$`
+
+// overridePattern matches the image and template override keys the templates of a chart read.
+var overridePattern = regexp.MustCompile(`\.Values\.global\.(imageOverrides|templateOverrides)(?:\.| ")([a-z0-9_]+)`)
+
+/*
+ValuesSchema generates the values.schema.json of the chart in the directory chartPath from its values.yaml
+and templates. Each value of values.yaml is given the type it is rendered with, maps and lists may be null,
+and values the chart defaults to null may take any type unless the renderer sets them. Values beyond those
+of values.yaml are allowed. The images the templates read are listed, and the template overrides they read
+are validated by the format their name implies. Charts that read template overrides reject the other keys
+with the prefix of the chart, so that a mistyped override is reported instead of being ignored.
+*/
+func ValuesSchema(fsys fs.FS, chartPath string) ([]byte, error) {
+	chartPath = path.Clean(chartPath)
+	data, err := fs.ReadFile(fsys, path.Join(chartPath, "values.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values, func(d *json.Decoder) *json.Decoder {
+		d.UseNumber()
+		return d
+	}); err != nil {
+		return nil, fmt.Errorf("failed to parse values of chart %s: %w", chartPath, err)
+	}
+
+	images, keys := map[string]bool{}, map[string]bool{}
+	err = fs.WalkDir(fsys, path.Join(chartPath, "templates"), func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		template, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		for _, match := range overridePattern.FindAllStringSubmatch(string(template), -1) {
+			if match[1] == "imageOverrides" {
+				images[match[2]] = true
+			} else {
+				keys[match[2]] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schema := valueSchema(values, reflect.TypeOf(Values{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["type"] = "object"
+	global, ok := schema["properties"].(map[string]interface{})["global"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("values of chart %s have no global values", chartPath)
+	}
+	if global["properties"] == nil {
+		global["properties"] = map[string]interface{}{}
+	}
+	global["properties"].(map[string]interface{})["imageOverrides"] = imageOverridesSchema(images)
+	global["properties"].(map[string]interface{})["templateOverrides"] = templateOverridesSchema(keys)
+
+	raw, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(raw, '\n'), nil
+}
+
+/*
+valueSchema returns the schema of a value of values.yaml. A value the renderer sets is given the type of the
+field of Values it is set from, as that is the value the chart is rendered with, and other values the type
+of their default.
+*/
+func valueSchema(value interface{}, t reflect.Type) map[string]interface{} {
+	if t != nil {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return typeSchema(t)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		schema := map[string]interface{}{"type": []string{"object", "null"}}
+		if len(v) > 0 {
+			properties := map[string]interface{}{}
+			for key, value := range v {
+				properties[key] = valueSchema(value, fieldType(t, key))
+			}
+			schema["properties"] = properties
+		}
+		return schema
+	case []interface{}:
+		return map[string]interface{}{"type": []string{"array", "null"}}
+	case string:
+		return map[string]interface{}{"type": "string"}
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return map[string]interface{}{"type": "number"}
+		}
+		return map[string]interface{}{"type": "integer"}
+	default:
+		if t != nil {
+			return typeSchema(t)
+		}
+		return map[string]interface{}{}
+	}
+}
+
+// typeSchema returns the schema of the JSON encoding of a value of type t.
+func typeSchema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 []string{"object", "null"},
+			"additionalProperties": typeSchema(t.Elem()),
+		}
+	case reflect.Slice:
+		return map[string]interface{}{"type": []string{"array", "null"}}
+	case reflect.Struct:
+		return map[string]interface{}{"type": []string{"object", "null"}}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// fieldType returns the type of the field of the struct type t that is encoded as key, or nil if there is none.
+func fieldType(t reflect.Type, key string) reflect.Type {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name == key {
+			return t.Field(i).Type
+		}
+	}
+	return nil
+}
+
+/*
+imageOverridesSchema returns the schema of the images a chart reads. The images are not required, as every
+chart is rendered with every image of the image manifest.
+*/
+func imageOverridesSchema(images map[string]bool) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":                 []string{"object", "null"},
+		"additionalProperties": map[string]interface{}{"type": "string"},
+	}
+	if len(images) > 0 {
+		properties := map[string]interface{}{}
+		for image := range images {
+			properties[image] = map[string]interface{}{"type": "string"}
+		}
+		schema["properties"] = properties
+	}
+	return schema
+}
+
+/*
+templateOverridesSchema returns the schema of the template overrides a chart reads. Only the prefixes of
+charts that read template overrides are restricted, as the prefix of a chart, such as cluster_api_, may be
+the start of the prefix of another chart.
+*/
+func templateOverridesSchema(keys map[string]bool) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":                 []string{"object", "null"},
+		"additionalProperties": map[string]interface{}{"type": "string"},
+	}
+	if len(keys) == 0 {
+		return schema
+	}
+
+	names := []string{}
+	prefixes := map[string]bool{}
+	properties := map[string]interface{}{}
+	for key := range keys {
+		names = append(names, key)
+		prefixes[templateOverridePrefix(key)] = true
+		property := map[string]interface{}{"type": "string"}
+		switch {
+		case strings.HasSuffix(key, "_request"), strings.HasSuffix(key, "_limit"),
+			strings.HasSuffix(key, "_storage"):
+			property["pattern"] = quantityPattern
+		case strings.HasSuffix(key, "_seconds"), strings.HasSuffix(key, "_threshold"):
+			property["pattern"] = "^[0-9]+$"
+		}
+		properties[key] = property
+	}
+	sort.Strings(names)
+	schema["properties"] = properties
+
+	prefixPatterns := []string{}
+	for prefix := range prefixes {
+		prefixPatterns = append(prefixPatterns, regexp.QuoteMeta(prefix))
+	}
+	sort.Strings(prefixPatterns)
+	schema["propertyNames"] = map[string]interface{}{
+		"if":   map[string]interface{}{"pattern": "^(" + strings.Join(prefixPatterns, "|") + ")"},
+		"then": map[string]interface{}{"enum": names},
+	}
+	return schema
+}
+
+// templateOverridePrefix returns the prefix of a template override key, such as console_mce_.
+func templateOverridePrefix(key string) string {
+	for _, part := range []string{"_deployment_", "_database_"} {
+		if i := strings.Index(key, part); i > 0 {
+			return key[:i+1]
+		}
+	}
+	return key[:strings.Index(key, "_")+1]
+}
@@ -0,0 +1,101 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	backplane "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderValuesSchema(t *testing.T) {
+	useTemplateFS(t, os.DirFS("../../"))
+	os.Setenv("ACM_HUB_OCP_VERSION", "4.16.0")
+	defer os.Unsetenv("ACM_HUB_OCP_VERSION")
+
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce"},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	images := map[string]string{"console_mce": "quay.io/test/console:test"}
+	chartPath := "pkg/templates/charts/toggle/console-mce"
+
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantKey   string
+	}{
+		{
+			name: "valid overrides",
+			overrides: map[string]string{
+				"console_mce_deployment_container_memory_limit":                   "512Mi",
+				"console_mce_deployment_container_liveness_probe_timeout_seconds": "5",
+				"maestro_database_storage":                                        "10Gi",
+			},
+		},
+		{
+			name:      "type mismatch",
+			overrides: map[string]string{"console_mce_deployment_container_readiness_probe_period_seconds": "ten"},
+			wantKey:   "console_mce_deployment_container_readiness_probe_period_seconds",
+		},
+		{
+			name:      "unknown key",
+			overrides: map[string]string{"console_mce_deployment_container_memory_limt": "512Mi"},
+			wantKey:   "console_mce_deployment_container_memory_limt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := RenderChart(chartPath, mce, images, tt.overrides)
+			if tt.wantKey == "" {
+				if len(errs) > 0 {
+					t.Fatalf("RenderChart() returned errors: %v", errs)
+				}
+				return
+			}
+			var valuesErr *ValuesError
+			if len(errs) != 1 || !errors.As(errs[0], &valuesErr) {
+				t.Fatalf("expected a ValuesError, got %v", errs)
+			}
+			if valuesErr.Chart != "console-mce" {
+				t.Errorf("expected the error for chart console-mce, got %s", valuesErr.Chart)
+			}
+			if !strings.Contains(valuesErr.Error(), tt.wantKey) {
+				t.Errorf("expected the error to point at %s, got %v", tt.wantKey, valuesErr)
+			}
+		})
+	}
+}
+
+func TestValuesSchemasGenerated(t *testing.T) {
+	fsys := os.DirFS("../../")
+	charts, err := fs.Glob(fsys, "pkg/templates/charts/*/*/Chart.yaml")
+	if err != nil {
+		t.Fatalf("failed to list charts: %v", err)
+	}
+	charts = append(charts, "hack/bundle-automation/chart-templates/Chart.yaml")
+
+	for _, chart := range charts {
+		chartPath := path.Dir(chart)
+		want, err := ValuesSchema(fsys, chartPath)
+		if err != nil {
+			t.Errorf("ValuesSchema(%s) returned error: %v", chartPath, err)
+			continue
+		}
+		got, err := fs.ReadFile(fsys, path.Join(chartPath, "values.schema.json"))
+		if err != nil {
+			t.Errorf("failed to read the values schema of %s: %v", chartPath, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("values schema of %s is out of date, run make values-schemas", chartPath)
+		}
+	}
+}
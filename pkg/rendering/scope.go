@@ -0,0 +1,130 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+var (
+	restMapperMu sync.RWMutex
+	// restMapper tells the namespaced kinds apart from the cluster-scoped kinds when rendering
+	restMapper meta.RESTMapper = NewStaticRESTMapper()
+)
+
+// clusterScopedKinds are the cluster-scoped kinds among the built-in kinds of Kubernetes.
+var clusterScopedKinds = map[string]bool{
+	"APIService":                       true,
+	"CertificateSigningRequest":        true,
+	"ClusterRole":                      true,
+	"ClusterRoleBinding":               true,
+	"ClusterTrustBundle":               true,
+	"ComponentStatus":                  true,
+	"CSIDriver":                        true,
+	"CSINode":                          true,
+	"CustomResourceDefinition":         true,
+	"DeviceClass":                      true,
+	"FlowSchema":                       true,
+	"IngressClass":                     true,
+	"IPAddress":                        true,
+	"MutatingAdmissionPolicy":          true,
+	"MutatingAdmissionPolicyBinding":   true,
+	"MutatingWebhookConfiguration":     true,
+	"Namespace":                        true,
+	"Node":                             true,
+	"PersistentVolume":                 true,
+	"PriorityClass":                    true,
+	"PriorityLevelConfiguration":       true,
+	"ResourceSlice":                    true,
+	"RuntimeClass":                     true,
+	"SelfSubjectAccessReview":          true,
+	"SelfSubjectReview":                true,
+	"SelfSubjectRulesReview":           true,
+	"ServiceCIDR":                      true,
+	"StorageClass":                     true,
+	"StorageVersionMigration":          true,
+	"SubjectAccessReview":              true,
+	"TokenReview":                      true,
+	"ValidatingAdmissionPolicy":        true,
+	"ValidatingAdmissionPolicyBinding": true,
+	"ValidatingWebhookConfiguration":   true,
+	"VolumeAttachment":                 true,
+	"VolumeAttributesClass":            true,
+}
+
+// staticKinds are the kinds the charts render beyond the built-in kinds of Kubernetes, with their scope.
+var staticKinds = map[schema.GroupVersionKind]meta.RESTScope{
+	{Group: "addon.open-cluster-management.io", Version: "v1alpha1", Kind: "AddOnDeploymentConfig"}:     meta.RESTScopeNamespace,
+	{Group: "addon.open-cluster-management.io", Version: "v1alpha1", Kind: "AddOnTemplate"}:             meta.RESTScopeRoot,
+	{Group: "addon.open-cluster-management.io", Version: "v1alpha1", Kind: "ClusterManagementAddOn"}:    meta.RESTScopeRoot,
+	{Group: "addon.open-cluster-management.io", Version: "v1beta1", Kind: "ClusterManagementAddOn"}:     meta.RESTScopeRoot,
+	{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService"}:                                meta.RESTScopeRoot,
+	{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}:                                      meta.RESTScopeNamespace,
+	{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"}:                                           meta.RESTScopeNamespace,
+	{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Kind: "Placement"}:                meta.RESTScopeNamespace,
+	{Group: "console.openshift.io", Version: "v1", Kind: "ConsolePlugin"}:                               meta.RESTScopeRoot,
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}:                             meta.RESTScopeNamespace,
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}:                             meta.RESTScopeNamespace,
+	{Group: "proxy.open-cluster-management.io", Version: "v1alpha1", Kind: "ManagedProxyConfiguration"}: meta.RESTScopeRoot,
+	{Group: "route.openshift.io", Version: "v1", Kind: "Route"}:                                         meta.RESTScopeNamespace,
+}
+
+/*
+NewStaticRESTMapper returns a RESTMapper of the built-in kinds of Kubernetes and of the other kinds the
+charts render, for rendering without a cluster. The operator maps the kinds with the RESTMapper of its
+manager instead, which knows every kind served by the cluster.
+*/
+func NewStaticRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for gvk := range clientgoscheme.Scheme.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal {
+			continue
+		}
+		scope := meta.RESTScopeNamespace
+		if clusterScopedKinds[gvk.Kind] {
+			scope = meta.RESTScopeRoot
+		}
+		mapper.Add(gvk, scope)
+	}
+	for gvk, scope := range staticKinds {
+		mapper.Add(gvk, scope)
+	}
+	return mapper
+}
+
+// SetRESTMapper sets the RESTMapper that tells which rendered kinds are namespaced. By default the
+// kinds are mapped with NewStaticRESTMapper.
+func SetRESTMapper(mapper meta.RESTMapper) {
+	restMapperMu.Lock()
+	defer restMapperMu.Unlock()
+	restMapper = mapper
+}
+
+// RESTMapper returns the RESTMapper that tells which rendered kinds are namespaced.
+func RESTMapper() meta.RESTMapper {
+	restMapperMu.RLock()
+	defer restMapperMu.RUnlock()
+	return restMapper
+}
+
+/*
+isNamespaced reports whether the kind of the template is namespaced. A kind that is not known, such as the
+kind of a CRD that is not installed yet, fails with an error meta.IsNoMatchError reports.
+*/
+func isNamespaced(template *unstructured.Unstructured) (bool, error) {
+	gvk := template.GroupVersionKind()
+	mapping, err := RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		return false, err
+	} else if err != nil {
+		return false, fmt.Errorf("failed to map the kind %s of %s: %w", gvk.String(), template.GetName(), err)
+	}
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
@@ -0,0 +1,139 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"testing"
+	"testing/fstest"
+
+	backplane "github.com/stolostron/backplane-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRenderNamespaceScoping(t *testing.T) {
+	chart := fstest.MapFS{
+		"charts/test/Chart.yaml": {Data: []byte("apiVersion: v2\nname: test\nversion: 0.1.0\n")},
+		"charts/test/templates/statefulset.yaml": {Data: []byte(
+			"apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: test\n")},
+		"charts/test/templates/pdb.yaml": {Data: []byte(
+			"apiVersion: policy/v1\nkind: PodDisruptionBudget\nmetadata:\n  name: test\n")},
+		"charts/test/templates/servicemonitor.yaml": {Data: []byte(
+			"apiVersion: monitoring.coreos.com/v1\nkind: ServiceMonitor\nmetadata:\n  name: test\n")},
+		"charts/test/templates/secret.yaml": {Data: []byte(
+			"apiVersion: v1\nkind: Secret\nmetadata:\n  name: test\n  namespace: other\n")},
+		"charts/test/templates/clusterrole.yaml": {Data: []byte(
+			"apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: test\n")},
+	}
+	useTemplateFS(t, chart)
+
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce"},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+
+	templates, errs := RenderChart("charts/test", mce, map[string]string{}, map[string]string{})
+	if len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors: %v", errs)
+	}
+	want := map[string]string{
+		"StatefulSet":         "test-ns",
+		"PodDisruptionBudget": "test-ns",
+		"ServiceMonitor":      "test-ns",
+		"Secret":              "other",
+		"ClusterRole":         "",
+	}
+	for _, template := range templates {
+		if template.GetNamespace() != want[template.GetKind()] {
+			t.Errorf("expected the %s in namespace %q, got %q", template.GetKind(), want[template.GetKind()],
+				template.GetNamespace())
+		}
+	}
+
+	chart["charts/test/templates/unknown.yaml"] = &fstest.MapFile{Data: []byte(
+		"apiVersion: example.com/v1\nkind: Unknown\nmetadata:\n  name: test\n")}
+	templates, errs = RenderChart("charts/test", mce, map[string]string{}, map[string]string{})
+	if len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors for an unknown kind: %v", errs)
+	}
+	for _, template := range templates {
+		if template.GetKind() == "Unknown" && template.GetNamespace() != "" {
+			t.Errorf("expected the unknown kind not to be scoped, got namespace %q", template.GetNamespace())
+		}
+	}
+}
+
+func TestRenderUninstalledCRDKind(t *testing.T) {
+	chart := fstest.MapFS{
+		"charts/test/Chart.yaml": {Data: []byte("apiVersion: v2\nname: test\nversion: 0.1.0\n")},
+		"charts/test/templates/addon.yaml": {Data: []byte(
+			"apiVersion: addon.open-cluster-management.io/v1alpha1\nkind: ClusterManagementAddOn\n" +
+				"metadata:\n  name: test\n")},
+		"charts/test/templates/serviceaccount.yaml": {Data: []byte(
+			"apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: test\n")},
+	}
+	useTemplateFS(t, chart)
+
+	// A cluster that serves the built-in kinds only, before the add-on CRDs are installed
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ServiceAccount"}, meta.RESTScopeNamespace)
+	previous := RESTMapper()
+	SetRESTMapper(mapper)
+	t.Cleanup(func() { SetRESTMapper(previous) })
+
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce"},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	templates, errs := RenderChart("charts/test", mce, map[string]string{}, map[string]string{})
+	if len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors: %v", errs)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected both resources to be rendered, got %d", len(templates))
+	}
+	for _, template := range templates {
+		want := map[string]string{"ServiceAccount": "test-ns", "ClusterManagementAddOn": ""}[template.GetKind()]
+		if template.GetNamespace() != want {
+			t.Errorf("expected the %s in namespace %q, got %q", template.GetKind(), want, template.GetNamespace())
+		}
+	}
+}
+
+func TestRenderCacheUninstalledCRDKind(t *testing.T) {
+	chart := fstest.MapFS{
+		"charts/test/Chart.yaml": {Data: []byte("apiVersion: v2\nname: test\nversion: 0.1.0\n")},
+		"charts/test/templates/addon.yaml": {Data: []byte(
+			"apiVersion: addon.open-cluster-management.io/v1alpha1\nkind: ClusterManagementAddOn\n" +
+				"metadata:\n  name: test\n")},
+	}
+	useTemplateFS(t, chart)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	previous := RESTMapper()
+	SetRESTMapper(mapper)
+	t.Cleanup(func() { SetRESTMapper(previous) })
+
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mce"},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "test-ns"},
+	}
+	cache := &RenderCache{}
+	if _, errs := cache.RenderChart("charts/test", mce, map[string]string{}, map[string]string{}); len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors: %v", errs)
+	}
+	if len(cache.charts) != 0 {
+		t.Fatalf("expected a chart with kinds the cluster does not know not to be cached")
+	}
+
+	// Once the CRD is installed the chart is cached
+	mapper.Add(schema.GroupVersionKind{Group: "addon.open-cluster-management.io", Version: "v1alpha1",
+		Kind: "ClusterManagementAddOn"}, meta.RESTScopeRoot)
+	if _, errs := cache.RenderChart("charts/test", mce, map[string]string{}, map[string]string{}); len(errs) > 0 {
+		t.Fatalf("RenderChart() returned errors: %v", errs)
+	}
+	if len(cache.charts) != 1 {
+		t.Errorf("expected the chart to be cached once every kind is known")
+	}
+}
@@ -18,6 +18,10 @@ const (
 	ComponentsUnavailableReason = "ComponentsUnavailable"
 	// DeployFailedReason is added when the hub fails to deploy a resource
 	DeployFailedReason = "FailedDeployingComponent"
+	// RenderFailedReason is added when the chart of a component fails to render
+	RenderFailedReason = "FailedRenderingComponent"
+	// InvalidOverridesReason is added when the template overrides do not match the values schema of a chart
+	InvalidOverridesReason = "InvalidTemplateOverrides"
 	// DeploySuccessReason is when all component have been deployed
 	DeploySuccessReason = "ComponentsDeployed"
 	// RequirementsNotMetReason is when there is something missing or misconfigured
@@ -35,6 +39,8 @@ const (
 	NamespaceTerminatingReason = "ManagedClusterNamespaceTerminating"
 	// PausedReason is added when the multiclusterengine is paused
 	PausedReason = "Paused"
+	// PlanModeReason is added when the multiclusterengine only plans changes without applying them
+	PlanModeReason = "PlanMode"
 	// UnsupportedConfigReason means the resource can't be deployed as intended based on current configuration
 	// settings
 	UnsupportedConfigReason = "UnsupportedConfiguration"
@@ -44,6 +50,8 @@ const (
 	ComponentsUpdatingReason = "UpdatingComponentResource"
 	// ExternalManagementReason is added when components are marked as externally managed
 	ExternalManagementReason = "ExternalManagement"
+	// DependencyBlockedReason is added when an enabled component is waiting on the components it depends on
+	DependencyBlockedReason = "BlockedOnDependency"
 )
 
 // NewCondition creates a new condition.
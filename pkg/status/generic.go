@@ -4,6 +4,7 @@ package status
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	bpv1 "github.com/stolostron/backplane-operator/api/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -60,6 +61,23 @@ func NewDisabledStatus(namespacedName types.NamespacedName, explanation string,
 	}
 }
 
+// NewBlockedStatus reports a component that is enabled but not installed because its dependencies are not.
+func NewBlockedStatus(namespacedName types.NamespacedName, dependencies []string) StatusReporter {
+	return StaticStatus{
+		NamespacedName: namespacedName,
+		Kind:           "Component",
+		Condition: bpv1.ComponentCondition{
+			Name:      namespacedName.Name,
+			Kind:      "Component",
+			Type:      "NotPresent",
+			Status:    metav1.ConditionTrue,
+			Reason:    DependencyBlockedReason,
+			Message:   fmt.Sprintf("Blocked on %s", strings.Join(dependencies, ", ")),
+			Available: true,
+		},
+	}
+}
+
 // DisabledStatus fulfills the StatusReporter interface for a component that should not be present. It ensures all resources are removed.
 // If in desired status will explain why with reason and message.
 type DisabledStatus struct {
@@ -59,6 +59,22 @@ func TestNewDisabledStatus(t *testing.T) {
 
 }
 
+func TestNewBlockedStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	sr := NewBlockedStatus(types.NamespacedName{Name: "hypershift-local-hosting", Namespace: "test-ns"},
+		[]string{"hypershift", "local-cluster"})
+
+	g.Expect(sr.GetName()).To(gomega.Equal("hypershift-local-hosting"))
+	g.Expect(sr.GetKind()).To(gomega.Equal("Component"))
+	g.Expect(sr.GetNamespace()).To(gomega.Equal("test-ns"))
+
+	condition := sr.Status(fake.NewClientBuilder().Build())
+	g.Expect(condition.Reason).To(gomega.Equal(DependencyBlockedReason))
+	g.Expect(condition.Message).To(gomega.Equal("Blocked on hypershift, local-cluster"))
+	g.Expect(condition.Available).To(gomega.BeTrue(), "Blocked components are not expected to be running")
+}
+
 func TestNewPresentStatus(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
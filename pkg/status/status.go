@@ -2,6 +2,11 @@
 package status
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
 	bpv1 "github.com/stolostron/backplane-operator/api/v1"
 	"github.com/stolostron/backplane-operator/pkg/version"
 
@@ -15,22 +20,41 @@ var (
 	log              = logf.Log.WithName("status")
 )
 
+// StatusTracker collects the status of the components reconciled for a MultiClusterEngine. Components and
+// conditions may be added concurrently.
 type StatusTracker struct {
+	mu         sync.Mutex
 	Client     client.Client
 	UID        string
 	Components []StatusReporter
 	Conditions []bpv1.MultiClusterEngineCondition
+	// Failures holds the components that failed to reconcile, keyed by component name
+	Failures map[string]bpv1.ComponentFailure
+	// Drift holds the managed resources found to differ from their rendered templates
+	Drift []bpv1.ResourceDrift
+	// ContainerOverrides holds the containers whose image, image pull policy or arguments are overridden
+	ContainerOverrides []bpv1.ContainerOverride
+	// Footprint holds the resources the Deployments of each installed component run with, keyed by component name
+	Footprint map[string]bpv1.ComponentFootprint
 }
 
 // Flush out any cached data being tracked, and assigns the tracker to a UID
 func (sm *StatusTracker) Reset(uid string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	sm.UID = uid
 	sm.Components = []StatusReporter{}
 	sm.Conditions = []bpv1.MultiClusterEngineCondition{}
+	sm.Failures = map[string]bpv1.ComponentFailure{}
+	sm.Drift = []bpv1.ResourceDrift{}
+	sm.ContainerOverrides = []bpv1.ContainerOverride{}
+	sm.Footprint = map[string]bpv1.ComponentFootprint{}
 }
 
 // Adds a StatusReporter to the list of statuses to watch
 func (sm *StatusTracker) AddComponent(sr StatusReporter) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	for _, c := range sm.Components {
 		if c.GetName() == sr.GetName() &&
 			c.GetNamespace() == sr.GetNamespace() &&
@@ -44,6 +68,8 @@ func (sm *StatusTracker) AddComponent(sr StatusReporter) {
 
 // Removes a StatusReporter from the list of statuses to watch
 func (sm *StatusTracker) RemoveComponent(sr StatusReporter) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	for i, c := range sm.Components {
 		if c.GetName() == sr.GetName() &&
 			c.GetNamespace() == sr.GetNamespace() &&
@@ -55,11 +81,102 @@ func (sm *StatusTracker) RemoveComponent(sr StatusReporter) {
 }
 
 func (sm *StatusTracker) AddCondition(c bpv1.MultiClusterEngineCondition) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	sm.Conditions = setCondition(sm.Conditions, c)
 }
 
+// RemoveCondition removes the condition with the given type
+func (sm *StatusTracker) RemoveCondition(condType bpv1.MultiClusterEngineConditionType) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.Conditions = filterOutCondition(sm.Conditions, condType)
+}
+
+// SetFailure records a component failure, replacing any failure previously recorded for the component
+func (sm *StatusTracker) SetFailure(f bpv1.ComponentFailure) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.Failures == nil {
+		sm.Failures = map[string]bpv1.ComponentFailure{}
+	}
+	sm.Failures[f.Name] = f
+}
+
+// ClearFailure removes the failure recorded for a component
+func (sm *StatusTracker) ClearFailure(name string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	delete(sm.Failures, name)
+}
+
+// GetFailure returns the failure recorded for a component, if any
+func (sm *StatusTracker) GetFailure(name string) (bpv1.ComponentFailure, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	f, ok := sm.Failures[name]
+	return f, ok
+}
+
+// AddDrift records a managed resource that differs from its rendered template
+func (sm *StatusTracker) AddDrift(d bpv1.ResourceDrift) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	for i, existing := range sm.Drift {
+		if existing.Kind == d.Kind && existing.Namespace == d.Namespace && existing.Name == d.Name {
+			sm.Drift[i] = d
+			return
+		}
+	}
+	sm.Drift = append(sm.Drift, d)
+}
+
+// GetDrift returns the drifted resources recorded, sorted by kind, namespace and name
+func (sm *StatusTracker) GetDrift() []bpv1.ResourceDrift {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.reportDrift()
+}
+
+// AddContainerOverride records the overrides applied to a container, replacing those previously recorded for it
+func (sm *StatusTracker) AddContainerOverride(o bpv1.ContainerOverride) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	for i, existing := range sm.ContainerOverrides {
+		if existing.Component == o.Component && existing.Deployment == o.Deployment &&
+			existing.Container == o.Container {
+			sm.ContainerOverrides[i] = o
+			return
+		}
+	}
+	sm.ContainerOverrides = append(sm.ContainerOverrides, o)
+}
+
+// AddComponentFootprint records the footprint of a component, replacing the footprint previously recorded for it
+func (sm *StatusTracker) AddComponentFootprint(f bpv1.ComponentFootprint) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.Footprint == nil {
+		sm.Footprint = map[string]bpv1.ComponentFootprint{}
+	}
+	sm.Footprint[f.Name] = f
+}
+
 func (sm *StatusTracker) ReportStatus(mce bpv1.MultiClusterEngine) bpv1.MultiClusterEngineStatus {
 	components := sm.reportComponents()
+	failures := sm.reportFailures()
+
+	// Summarize component failures in a single condition
+	if len(failures) > 0 {
+		names := []string{}
+		for _, f := range failures {
+			names = append(names, f.Name)
+		}
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEngineComponentFailure, metav1.ConditionTrue,
+			ApplyFailedReason, fmt.Sprintf("Failed to reconcile components: %s", strings.Join(names, ", "))))
+	} else {
+		sm.Conditions = filterOutCondition(sm.Conditions, bpv1.MultiClusterEngineComponentFailure)
+	}
 
 	// Infer available condition from component health
 	if allComponentsReady(components) {
@@ -72,7 +189,7 @@ func (sm *StatusTracker) ReportStatus(mce bpv1.MultiClusterEngine) bpv1.MultiClu
 	}
 
 	conditions := sm.reportConditions()
-	phase := sm.reportPhase(mce, components, conditions)
+	phase := sm.reportPhase(mce, components, failures, conditions)
 
 	currentVersion := mce.Status.CurrentVersion
 	if phase == bpv1.MultiClusterEnginePhaseAvailable {
@@ -80,11 +197,15 @@ func (sm *StatusTracker) ReportStatus(mce bpv1.MultiClusterEngine) bpv1.MultiClu
 	}
 
 	return bpv1.MultiClusterEngineStatus{
-		Components:     components,
-		Conditions:     conditions,
-		Phase:          phase,
-		DesiredVersion: version.Version,
-		CurrentVersion: currentVersion,
+		Components:         components,
+		ComponentFailures:  failures,
+		DriftedResources:   sm.reportDrift(),
+		ContainerOverrides: sm.reportContainerOverrides(),
+		Footprint:          sm.reportFootprint(),
+		Conditions:         conditions,
+		Phase:              phase,
+		DesiredVersion:     version.Version,
+		CurrentVersion:     currentVersion,
 	}
 }
 
@@ -96,11 +217,66 @@ func (sm *StatusTracker) reportComponents() []bpv1.ComponentCondition {
 	return components
 }
 
+// reportFailures returns the recorded component failures sorted by component name
+func (sm *StatusTracker) reportFailures() []bpv1.ComponentFailure {
+	failures := []bpv1.ComponentFailure{}
+	for _, f := range sm.Failures {
+		failures = append(failures, f)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Name < failures[j].Name
+	})
+	return failures
+}
+
+// reportDrift returns the drifted resources sorted by kind, namespace and name
+func (sm *StatusTracker) reportDrift() []bpv1.ResourceDrift {
+	drift := append([]bpv1.ResourceDrift{}, sm.Drift...)
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Kind != drift[j].Kind {
+			return drift[i].Kind < drift[j].Kind
+		}
+		if drift[i].Namespace != drift[j].Namespace {
+			return drift[i].Namespace < drift[j].Namespace
+		}
+		return drift[i].Name < drift[j].Name
+	})
+	return drift
+}
+
+// reportContainerOverrides returns the overridden containers sorted by component, deployment and container
+func (sm *StatusTracker) reportContainerOverrides() []bpv1.ContainerOverride {
+	overrides := append([]bpv1.ContainerOverride{}, sm.ContainerOverrides...)
+	sort.Slice(overrides, func(i, j int) bool {
+		if overrides[i].Component != overrides[j].Component {
+			return overrides[i].Component < overrides[j].Component
+		}
+		if overrides[i].Deployment != overrides[j].Deployment {
+			return overrides[i].Deployment < overrides[j].Deployment
+		}
+		return overrides[i].Container < overrides[j].Container
+	})
+	return overrides
+}
+
+// reportFootprint returns the footprints of the components sorted by component name
+func (sm *StatusTracker) reportFootprint() []bpv1.ComponentFootprint {
+	footprint := []bpv1.ComponentFootprint{}
+	for _, f := range sm.Footprint {
+		footprint = append(footprint, f)
+	}
+	sort.Slice(footprint, func(i, j int) bool {
+		return footprint[i].Name < footprint[j].Name
+	})
+	return footprint
+}
+
 func (sm *StatusTracker) reportConditions() []bpv1.MultiClusterEngineCondition {
 	return sm.Conditions
 }
 
-func (sm *StatusTracker) reportPhase(mce bpv1.MultiClusterEngine, components []bpv1.ComponentCondition, conditions []bpv1.MultiClusterEngineCondition) bpv1.PhaseType {
+func (sm *StatusTracker) reportPhase(mce bpv1.MultiClusterEngine, components []bpv1.ComponentCondition,
+	failures []bpv1.ComponentFailure, conditions []bpv1.MultiClusterEngineCondition) bpv1.PhaseType {
 	progress := getCondition(conditions, bpv1.MultiClusterEngineProgressing)
 
 	for _, condition := range conditions {
@@ -124,8 +300,8 @@ func (sm *StatusTracker) reportPhase(mce bpv1.MultiClusterEngine, components []b
 		return bpv1.MultiClusterEnginePhaseProgressing
 	}
 
-	// If status contains failure status return error
-	if ok := ConditionPresentWithSubstring(conditions, string(bpv1.MultiClusterEngineComponentFailure)); ok {
+	// If a component failed to reconcile return error
+	if len(failures) > 0 {
 		return bpv1.MultiClusterEnginePhaseError
 	}
 
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -263,3 +265,102 @@ func TestStatusTracker_Reset(t *testing.T) {
 		}
 	})
 }
+
+func TestStatusTracker_ReportFailures(t *testing.T) {
+	tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
+	tracker.Reset("")
+	tracker.AddComponent(MockStatus{NamespacedName: types.NamespacedName{Name: "mock-name", Namespace: "mock-ns"}})
+	tracker.SetFailure(bpv1.ComponentFailure{Name: "discovery", Reason: ApplyFailedReason, Failures: 1})
+	tracker.SetFailure(bpv1.ComponentFailure{Name: "console-mce", Reason: DeployFailedReason, Failures: 3})
+
+	mce := bpv1.MultiClusterEngine{}
+	got := tracker.ReportStatus(mce)
+	if len(got.ComponentFailures) != 2 || got.ComponentFailures[0].Name != "console-mce" ||
+		got.ComponentFailures[1].Name != "discovery" {
+		t.Errorf("StatusTracker.ReportStatus() componentFailures = %v, want console-mce and discovery",
+			got.ComponentFailures)
+	}
+	if got.Phase != bpv1.MultiClusterEnginePhaseError {
+		t.Errorf("StatusTracker.ReportStatus() phase = %v, want %v", got.Phase, bpv1.MultiClusterEnginePhaseError)
+	}
+	cond := getCondition(got.Conditions, bpv1.MultiClusterEngineComponentFailure)
+	if cond == nil || cond.Message != "Failed to reconcile components: console-mce, discovery" {
+		t.Errorf("StatusTracker.ReportStatus() expected a %s condition listing the failing components, got %v",
+			bpv1.MultiClusterEngineComponentFailure, cond)
+	}
+
+	tracker.ClearFailure("discovery")
+	tracker.ClearFailure("console-mce")
+	got = tracker.ReportStatus(mce)
+	if len(got.ComponentFailures) != 0 {
+		t.Errorf("StatusTracker.ReportStatus() componentFailures = %v, want none", got.ComponentFailures)
+	}
+	if getCondition(got.Conditions, bpv1.MultiClusterEngineComponentFailure) != nil {
+		t.Errorf("StatusTracker.ReportStatus() expected the %s condition to be removed",
+			bpv1.MultiClusterEngineComponentFailure)
+	}
+}
+
+func TestStatusTracker_ReportDrift(t *testing.T) {
+	tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
+	tracker.Reset("")
+	tracker.AddDrift(bpv1.ResourceDrift{Kind: "Deployment", Namespace: "ns", Name: "b", Fields: []string{"spec.replicas"}})
+	tracker.AddDrift(bpv1.ResourceDrift{Kind: "ConfigMap", Namespace: "ns", Name: "a", Fields: []string{"data.key"}})
+	tracker.AddDrift(bpv1.ResourceDrift{Kind: "Deployment", Namespace: "ns", Name: "b",
+		Fields: []string{"spec.replicas", "spec.template.spec.containers[0].image"}})
+
+	got := tracker.ReportStatus(bpv1.MultiClusterEngine{}).DriftedResources
+	if len(got) != 2 || got[0].Kind != "ConfigMap" || got[1].Kind != "Deployment" {
+		t.Fatalf("StatusTracker.ReportStatus() driftedResources = %v, want ConfigMap then Deployment", got)
+	}
+	if len(got[1].Fields) != 2 {
+		t.Errorf("StatusTracker.ReportStatus() expected the latest drift of a resource to be kept, got %v", got[1])
+	}
+}
+
+func TestStatusTracker_ReportContainerOverrides(t *testing.T) {
+	tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
+	tracker.Reset("")
+	tracker.AddContainerOverride(bpv1.ContainerOverride{Component: "hive", Deployment: "hive-operator",
+		Container: "hive-operator", Args: []string{"--v=4"}})
+	tracker.AddContainerOverride(bpv1.ContainerOverride{Component: "console-mce", Deployment: "console-mce-console",
+		Container: "console", Image: "quay.io/test/console:fix"})
+	tracker.AddContainerOverride(bpv1.ContainerOverride{Component: "hive", Deployment: "hive-operator",
+		Container: "hive-operator", Args: []string{"--v=4"}, ImagePullPolicy: corev1.PullAlways})
+
+	got := tracker.ReportStatus(bpv1.MultiClusterEngine{}).ContainerOverrides
+	if len(got) != 2 || got[0].Component != "console-mce" || got[1].Component != "hive" {
+		t.Fatalf("StatusTracker.ReportStatus() containerOverrides = %v, want console-mce then hive", got)
+	}
+	if got[1].ImagePullPolicy != corev1.PullAlways {
+		t.Errorf("StatusTracker.ReportStatus() expected the latest overrides of a container to be kept, got %v", got[1])
+	}
+
+	tracker.Reset("")
+	if got := tracker.ReportStatus(bpv1.MultiClusterEngine{}).ContainerOverrides; len(got) != 0 {
+		t.Errorf("StatusTracker.ReportStatus() expected no container overrides after a reset, got %v", got)
+	}
+}
+
+func TestStatusTracker_ReportFootprint(t *testing.T) {
+	tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
+	tracker.Reset("")
+	tracker.AddComponentFootprint(bpv1.ComponentFootprint{Name: "hive", Deployments: 1, Replicas: 1, Containers: 1})
+	tracker.AddComponentFootprint(bpv1.ComponentFootprint{Name: "console-mce", Deployments: 1, Replicas: 2,
+		Containers: 2})
+	tracker.AddComponentFootprint(bpv1.ComponentFootprint{Name: "hive", Deployments: 1, Replicas: 2, Containers: 2,
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}})
+
+	got := tracker.ReportStatus(bpv1.MultiClusterEngine{}).Footprint
+	if len(got) != 2 || got[0].Name != "console-mce" || got[1].Name != "hive" {
+		t.Fatalf("StatusTracker.ReportStatus() footprint = %v, want console-mce then hive", got)
+	}
+	if got[1].Replicas != 2 {
+		t.Errorf("StatusTracker.ReportStatus() expected the latest footprint of a component to be kept, got %v", got[1])
+	}
+
+	tracker.Reset("")
+	if got := tracker.ReportStatus(bpv1.MultiClusterEngine{}).Footprint; len(got) != 0 {
+		t.Errorf("StatusTracker.ReportStatus() expected no footprint after a reset, got %v", got)
+	}
+}
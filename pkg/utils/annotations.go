@@ -65,12 +65,31 @@ var (
 	AnnotationMCEPause           = "installer.multicluster.openshift.io/pause"
 	DeprecatedAnnotationMCEPause = "pause"
 
+	/*
+		AnnotationPlan is an annotation used in multiclusterengine to run the operator in plan mode. In plan mode
+		the rendered resources are applied with a server-side dry run and the resulting changes are written to a
+		ConfigMap instead of being applied.
+	*/
+	AnnotationPlan = "installer.multicluster.openshift.io/plan"
+
+	/*
+		AnnotationDriftReportOnly is an annotation used in multiclusterengine to only report drift of managed
+		resources. Drifted resources are recorded but not overwritten with their rendered templates.
+	*/
+	AnnotationDriftReportOnly = "installer.multicluster.openshift.io/drift-report-only"
+
 	/*
 		AnnotationReleaseVersion is an annotation used to indicate the release version that should be applied to all
 		resources managed by the backplane operator.
 	*/
 	AnnotationReleaseVersion = "installer.multicluster.openshift.io/release-version"
 
+	/*
+		AnnotationTemplateHash is an annotation set on the resources applied by the backplane operator with the
+		hash of their rendered template. Resources whose hash matches the rendered template are not applied again.
+	*/
+	AnnotationTemplateHash = "installer.multicluster.openshift.io/template-hash"
+
 	/*
 		AnnotationTemplateOverridesCM is an annotation used in multiclusterengine to specify a custom ConfigMap
 		containing resource template overrides.
@@ -118,6 +137,22 @@ func IsPaused(instance *backplanev1.MultiClusterEngine) bool {
 	return IsAnnotationTrue(instance, AnnotationMCEPause) || IsAnnotationTrue(instance, DeprecatedAnnotationMCEPause)
 }
 
+/*
+IsPlanMode checks if the MultiClusterEngine instance is annotated to run in plan mode.
+It returns true if changes should only be planned, otherwise false.
+*/
+func IsPlanMode(instance *backplanev1.MultiClusterEngine) bool {
+	return IsAnnotationTrue(instance, AnnotationPlan)
+}
+
+/*
+IsDriftReportOnly checks if the MultiClusterEngine instance is annotated to only report drift.
+It returns true if drifted resources should not be overwritten, otherwise false.
+*/
+func IsDriftReportOnly(instance *backplanev1.MultiClusterEngine) bool {
+	return IsAnnotationTrue(instance, AnnotationDriftReportOnly)
+}
+
 /*
 IsAnnotationTrue checks if a specific annotation key in the given instance is set to "true".
 */
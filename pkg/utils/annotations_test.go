@@ -42,6 +42,30 @@ func TestIsPaused(t *testing.T) {
 
 }
 
+func TestIsPlanMode(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{}
+	if IsPlanMode(mce) {
+		t.Errorf("IsPlanMode() = true, want false")
+	}
+
+	mce.SetAnnotations(map[string]string{AnnotationPlan: "true"})
+	if !IsPlanMode(mce) {
+		t.Errorf("IsPlanMode() = false, want true")
+	}
+}
+
+func TestIsDriftReportOnly(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{}
+	if IsDriftReportOnly(mce) {
+		t.Errorf("IsDriftReportOnly() = true, want false")
+	}
+
+	mce.SetAnnotations(map[string]string{AnnotationDriftReportOnly: "true"})
+	if !IsDriftReportOnly(mce) {
+		t.Errorf("IsDriftReportOnly() = false, want true")
+	}
+}
+
 func Test_getAnnotationEdgeManagement(t *testing.T) {
 	instance := &backplanev1.MultiClusterEngine{
 		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationEdgeManagerEnabled: "true"}},
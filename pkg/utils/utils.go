@@ -32,6 +32,9 @@ const (
 
 	// OpenShiftClusterMonitoringLabel is the label for OpenShift cluster monitoring.
 	OpenShiftClusterMonitoringLabel = "openshift.io/cluster-monitoring"
+
+	// ComponentLabel is the label identifying the component a resource is applied for.
+	ComponentLabel = "installer.multicluster.openshift.io/component"
 )
 
 const (
@@ -116,6 +119,17 @@ func AddBackplaneConfigLabels(u client.Object, name string) {
 	u.SetLabels(labels)
 }
 
+// AddComponentLabel labels the resource as applied for the named component.
+func AddComponentLabel(u client.Object, component string) {
+	labels := make(map[string]string)
+	for key, value := range u.GetLabels() {
+		labels[key] = value
+	}
+	labels[ComponentLabel] = component
+
+	u.SetLabels(labels)
+}
+
 // CoreToUnstructured converts a Core Kube resource to unstructured
 func CoreToUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
 	content, err := json.Marshal(obj)